@@ -0,0 +1,90 @@
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ParseICS returns the dates covered by the events of an iCalendar file, e.g. an exported holiday calendar. Events
+// without an end cover their start date, the end date of an event is exclusive.
+func ParseICS(r io.Reader) ([]string, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var dates []string
+	var start, end time.Time
+	inEvent := false
+	for _, line := range lines {
+		name, value := splitProperty(line)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			inEvent = true
+			start, end = time.Time{}, time.Time{}
+		case name == "END" && value == "VEVENT":
+			inEvent = false
+			if start.IsZero() {
+				return nil, fmt.Errorf("event without DTSTART")
+			}
+			if end.IsZero() || !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+			for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+				dates = append(dates, day.Format(DateLayout))
+			}
+		case inEvent && name == "DTSTART":
+			if start, err = parseDate(value); err != nil {
+				return nil, err
+			}
+		case inEvent && name == "DTEND":
+			if end, err = parseDate(value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return dates, nil
+}
+
+// unfold joins the continuation lines, which start with a space or tab
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// splitProperty returns the upper cased name without parameters and the value of a content line
+func splitProperty(line string) (string, string) {
+	i := strings.Index(line, ":")
+	if i < 0 {
+		return "", ""
+	}
+	name := line[:i]
+	if j := strings.Index(name, ";"); j >= 0 {
+		name = name[:j]
+	}
+	return strings.ToUpper(name), strings.TrimSpace(line[i+1:])
+}
+
+// parseDate parses the date of a DATE or DATE-TIME value like 20211225 or 20211225T000000Z
+func parseDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	date, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return date, nil
+}
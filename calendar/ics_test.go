@@ -0,0 +1,58 @@
+package calendar_test
+
+import (
+	"github.com/nadilas/todo/calendar"
+	. "github.com/nadilas/todo/workflows/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+const holidaysICS = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20211224\r\n" +
+	"SUMMARY:Christmas Eve\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20211225\r\n" +
+	"DTEND;VALUE=DATE:20211227\r\n" +
+	"SUMMARY:Christmas\r\n" +
+	" Holidays\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICS(t *testing.T) {
+	var dates []string
+	var err error
+
+	Scenario(t, "events cover their dates",
+		When(parsed(&dates, &err, holidaysICS)),
+		Then(func(t *testing.T) {
+			require.NoError(t, err)
+			assert.Equal(t, []string{"2021-12-24", "2021-12-25", "2021-12-26"}, dates)
+		}),
+	)
+	Scenario(t, "date times are reduced to their date",
+		When(parsed(&dates, &err, "BEGIN:VEVENT\nDTSTART:20210101T000000Z\nEND:VEVENT\n")),
+		Then(func(t *testing.T) {
+			require.NoError(t, err)
+			assert.Equal(t, []string{"2021-01-01"}, dates)
+		}),
+	)
+	Scenario(t, "event without start is rejected",
+		When(parsed(&dates, &err, "BEGIN:VEVENT\nSUMMARY:nothing\nEND:VEVENT\n")),
+		Then(failedWith(&err, "event without DTSTART")),
+	)
+	Scenario(t, "invalid date is rejected",
+		When(parsed(&dates, &err, "BEGIN:VEVENT\nDTSTART:2021-01-01\nEND:VEVENT\n")),
+		Then(failedWith(&err, "invalid date")),
+	)
+}
+
+func parsed(dates *[]string, err *error, ics string) func(t *testing.T) {
+	return func(t *testing.T) {
+		*dates, *err = calendar.ParseICS(strings.NewReader(ics))
+	}
+}
@@ -0,0 +1,180 @@
+// Package calendar decides when reminders may be delivered and when recurring todos are due again. Policies and
+// recurrences are plain data, so workflows can carry them and evaluate them deterministically against workflow.Now.
+package calendar
+
+import (
+	"fmt"
+	"github.com/nadilas/todo/config"
+	"os"
+	"strings"
+	"time"
+)
+
+// DateLayout is the layout of holidays
+const DateLayout = "2006-01-02"
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// Policy describes when a user may receive reminders
+type Policy struct {
+	// Timezone is the IANA timezone the policy is evaluated in, UTC if not set
+	Timezone string
+	// QuietStart and QuietEnd are offsets from midnight. A window ending before it starts spans midnight, an empty
+	// window means no quiet hours.
+	QuietStart time.Duration
+	QuietEnd   time.Duration
+	// WorkingDays are the days reminders are delivered on, every day if empty
+	WorkingDays []time.Weekday
+	// Holidays are dates in DateLayout reminders are not delivered on
+	Holidays []string
+}
+
+// NextAllowed returns t if a reminder may be delivered at t, otherwise the start of the next allowed slot. A nil
+// policy allows every time.
+func (p *Policy) NextAllowed(t time.Time) time.Time {
+	if p == nil {
+		return t
+	}
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	candidate := t.In(loc)
+	// two years without an allowed day means the policy never allows delivery
+	for i := 0; i < 2*366*2; i++ {
+		nextMidnight := time.Date(candidate.Year(), candidate.Month(), candidate.Day()+1, 0, 0, 0, 0, loc)
+		if !p.allowedDay(candidate) {
+			candidate = nextMidnight
+			continue
+		}
+		offset := timeOfDay(candidate)
+		if !p.quiet(offset) {
+			return candidate
+		}
+		if p.QuietStart < p.QuietEnd || offset < p.QuietEnd {
+			// the quiet hours end later today
+			candidate = time.Date(candidate.Year(), candidate.Month(), candidate.Day(), int(p.QuietEnd/time.Hour), int(p.QuietEnd%time.Hour/time.Minute), 0, 0, loc)
+			continue
+		}
+		candidate = nextMidnight
+	}
+	return t
+}
+
+func (p *Policy) allowedDay(t time.Time) bool {
+	for _, holiday := range p.Holidays {
+		if holiday == t.Format(DateLayout) {
+			return false
+		}
+	}
+	if len(p.WorkingDays) == 0 {
+		return true
+	}
+	for _, day := range p.WorkingDays {
+		if day == t.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) quiet(offset time.Duration) bool {
+	switch {
+	case p.QuietStart == p.QuietEnd:
+		return false
+	case p.QuietStart < p.QuietEnd:
+		return offset >= p.QuietStart && offset < p.QuietEnd
+	default:
+		return offset >= p.QuietStart || offset < p.QuietEnd
+	}
+}
+
+func timeOfDay(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+}
+
+// PolicyFromConfig reads a policy from a "delivery" config section:
+//
+//	timezone: Europe/Berlin
+//	quiet_hours: 22:00-07:00
+//	working_days: mon,tue,wed,thu,fri
+//	holidays: 2021-12-25,2021-12-26
+//	holidays_ics: /etc/todo/holidays.ics
+func PolicyFromConfig(cfg config.Provider) (*Policy, error) {
+	p := &Policy{
+		Timezone: cfg.GetString("timezone"),
+	}
+	if _, err := time.LoadLocation(p.Timezone); err != nil {
+		return nil, fmt.Errorf("invalid delivery timezone: %w", err)
+	}
+
+	if quietHours := cfg.GetString("quiet_hours"); quietHours != "" {
+		bounds := strings.Split(quietHours, "-")
+		if len(bounds) != 2 {
+			return nil, fmt.Errorf("invalid delivery quiet_hours %q: expected 22:00-07:00", quietHours)
+		}
+		var err error
+		if p.QuietStart, err = parseTimeOfDay(bounds[0]); err != nil {
+			return nil, err
+		}
+		if p.QuietEnd, err = parseTimeOfDay(bounds[1]); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range splitList(cfg.GetString("working_days")) {
+		day, ok := weekdays[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("invalid delivery working day %q: expected one of mon,tue,wed,thu,fri,sat,sun", name)
+		}
+		p.WorkingDays = append(p.WorkingDays, day)
+	}
+
+	for _, holiday := range splitList(cfg.GetString("holidays")) {
+		if _, err := time.Parse(DateLayout, holiday); err != nil {
+			return nil, fmt.Errorf("invalid delivery holiday: %w", err)
+		}
+		p.Holidays = append(p.Holidays, holiday)
+	}
+
+	if path := cfg.GetString("holidays_ics"); path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading holidays: %w", err)
+		}
+		defer f.Close()
+		holidays, err := ParseICS(f)
+		if err != nil {
+			return nil, fmt.Errorf("parsing holidays %s: %w", path, err)
+		}
+		p.Holidays = append(p.Holidays, holidays...)
+	}
+	return p, nil
+}
+
+func parseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid delivery time of day %q: expected 15:04", value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,124 @@
+package calendar_test
+
+import (
+	"github.com/nadilas/todo/calendar"
+	"github.com/nadilas/todo/config"
+	. "github.com/nadilas/todo/workflows/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var berlin, _ = time.LoadLocation("Europe/Berlin")
+
+var officeHours = &calendar.Policy{
+	Timezone:    "Europe/Berlin",
+	QuietStart:  time.Hour * 18,
+	QuietEnd:    time.Hour * 8,
+	WorkingDays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	Holidays:    []string{"2021-12-24"},
+}
+
+func TestNextAllowed(t *testing.T) {
+	Scenario(t, "working time is allowed",
+		Then(nextAllowed(officeHours, at(2021, 8, 10, 11, 30), at(2021, 8, 10, 11, 30))),
+	)
+	Scenario(t, "early morning is deferred to the end of the quiet hours",
+		Then(nextAllowed(officeHours, at(2021, 8, 10, 3, 0), at(2021, 8, 10, 8, 0))),
+	)
+	Scenario(t, "evening is deferred to the next morning",
+		Then(nextAllowed(officeHours, at(2021, 8, 10, 21, 0), at(2021, 8, 11, 8, 0))),
+	)
+	Scenario(t, "weekend is deferred to monday",
+		Then(nextAllowed(officeHours, at(2021, 8, 7, 12, 0), at(2021, 8, 9, 8, 0))),
+	)
+	Scenario(t, "holiday is deferred to the next working day",
+		Then(nextAllowed(officeHours, at(2021, 12, 23, 19, 0), at(2021, 12, 27, 8, 0))),
+	)
+	Scenario(t, "quiet hours within a day",
+		Then(nextAllowed(&calendar.Policy{QuietStart: time.Hour * 12, QuietEnd: time.Hour * 13}, time.Date(2021, 8, 10, 12, 15, 0, 0, time.UTC), time.Date(2021, 8, 10, 13, 0, 0, 0, time.UTC))),
+	)
+	Scenario(t, "nil policy allows every time",
+		Then(nextAllowed(nil, at(2021, 8, 7, 3, 0), at(2021, 8, 7, 3, 0))),
+	)
+}
+
+func TestPolicyFromConfig(t *testing.T) {
+	var policy *calendar.Policy
+	var err error
+
+	Scenario(t, "policy is read from the delivery section",
+		When(policyFrom(&policy, &err, map[string]string{
+			"TODO_DELIVERY_TIMEZONE":     "Europe/Berlin",
+			"TODO_DELIVERY_QUIET_HOURS":  "18:00-08:00",
+			"TODO_DELIVERY_WORKING_DAYS": "mon, tue,wed,thu,FRI",
+			"TODO_DELIVERY_HOLIDAYS":     "2021-12-24",
+		})),
+		Then(func(t *testing.T) {
+			require.NoError(t, err)
+			assert.Equal(t, officeHours, policy)
+		}),
+	)
+	Scenario(t, "holidays are read from an ics file",
+		When(func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "holidays.ics")
+			require.NoError(t, ioutil.WriteFile(path, []byte(holidaysICS), 0600))
+			policyFrom(&policy, &err, map[string]string{
+				"TODO_DELIVERY_HOLIDAYS":     "2021-12-31",
+				"TODO_DELIVERY_HOLIDAYS_ICS": path,
+			})(t)
+		}),
+		Then(func(t *testing.T) {
+			require.NoError(t, err)
+			assert.Equal(t, []string{"2021-12-31", "2021-12-24", "2021-12-25", "2021-12-26"}, policy.Holidays)
+		}),
+	)
+	Scenario(t, "invalid quiet hours are rejected",
+		When(policyFrom(&policy, &err, map[string]string{"TODO_DELIVERY_QUIET_HOURS": "22:00"})),
+		Then(failedWith(&err, "invalid delivery quiet_hours")),
+	)
+	Scenario(t, "invalid working day is rejected",
+		When(policyFrom(&policy, &err, map[string]string{"TODO_DELIVERY_WORKING_DAYS": "monday"})),
+		Then(failedWith(&err, "invalid delivery working day")),
+	)
+	Scenario(t, "invalid holiday is rejected",
+		When(policyFrom(&policy, &err, map[string]string{"TODO_DELIVERY_HOLIDAYS": "24.12.2021"})),
+		Then(failedWith(&err, "invalid delivery holiday")),
+	)
+	Scenario(t, "unknown timezone is rejected",
+		When(policyFrom(&policy, &err, map[string]string{"TODO_DELIVERY_TIMEZONE": "Mars/Olympus_Mons"})),
+		Then(failedWith(&err, "invalid delivery timezone")),
+	)
+}
+
+func at(year int, month time.Month, day, hour, min int) time.Time {
+	return time.Date(year, month, day, hour, min, 0, 0, berlin)
+}
+
+func nextAllowed(policy *calendar.Policy, t time.Time, expected time.Time) func(t *testing.T) {
+	return func(st *testing.T) {
+		actual := policy.NextAllowed(t)
+		assert.True(st, expected.Equal(actual), "expected %s, got %s", expected, actual)
+	}
+}
+
+func policyFrom(policy **calendar.Policy, err *error, env map[string]string) func(t *testing.T) {
+	return func(t *testing.T) {
+		provider, loadErr := config.New(config.Defaults, "", func(key string) (string, bool) {
+			value, ok := env[key]
+			return value, ok
+		})
+		require.NoError(t, loadErr)
+		*policy, *err = calendar.PolicyFromConfig(provider.Sub("delivery"))
+	}
+}
+
+func failedWith(err *error, message string) func(t *testing.T) {
+	return func(t *testing.T) {
+		require.Error(t, *err)
+		assert.Contains(t, (*err).Error(), message)
+	}
+}
@@ -0,0 +1,186 @@
+package calendar
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequencies of a Recurrence
+const (
+	Daily   = "DAILY"
+	Weekly  = "WEEKLY"
+	Monthly = "MONTHLY"
+	Yearly  = "YEARLY"
+)
+
+// maxSkipped bounds the periods skipped looking for the next occurrence, e.g. months without a 31st
+const maxSkipped = 1000
+
+var byDays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Recurrence is the subset of an RFC 5545 RRULE supported by recurring todos, e.g. FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH.
+// Weeks start on Monday. Occurrences keep the time of day of the first one in its location.
+type Recurrence struct {
+	// Freq is Daily, Weekly, Monthly or Yearly
+	Freq     string
+	Interval int
+	// ByDay limits daily and weekly recurrences to the weekdays, sorted from Monday
+	ByDay []time.Weekday
+	// Count is the number of occurrences, unlimited if 0
+	Count int
+	// Until is the latest time of an occurrence, unlimited if zero
+	Until time.Time
+}
+
+// ParseRecurrence parses the FREQ, INTERVAL, BYDAY, COUNT and UNTIL parts of an RRULE, which may start with "RRULE:".
+// An UNTIL without time covers the whole day, floating times are taken as UTC.
+func ParseRecurrence(rule string) (*Recurrence, error) {
+	r := &Recurrence{Interval: 1}
+	for _, part := range strings.Split(strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:"), ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid recurrence: %q is not a NAME=value part", part)
+		}
+		name, value := strings.ToUpper(strings.TrimSpace(kv[0])), strings.ToUpper(strings.TrimSpace(kv[1]))
+		var err error
+		switch name {
+		case "FREQ":
+			if value != Daily && value != Weekly && value != Monthly && value != Yearly {
+				return nil, fmt.Errorf("invalid recurrence: unsupported FREQ %s", value)
+			}
+			r.Freq = value
+		case "INTERVAL":
+			if r.Interval, err = strconv.Atoi(value); err != nil || r.Interval < 1 {
+				return nil, fmt.Errorf("invalid recurrence: INTERVAL %s is not a positive number", value)
+			}
+		case "COUNT":
+			if r.Count, err = strconv.Atoi(value); err != nil || r.Count < 1 {
+				return nil, fmt.Errorf("invalid recurrence: COUNT %s is not a positive number", value)
+			}
+		case "UNTIL":
+			if r.Until, err = parseUntil(value); err != nil {
+				return nil, err
+			}
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := byDays[day]
+				if !ok {
+					return nil, fmt.Errorf("invalid recurrence: unsupported BYDAY %s", day)
+				}
+				r.ByDay = append(r.ByDay, weekday)
+			}
+			sort.Slice(r.ByDay, func(i, j int) bool {
+				return weekIndex(r.ByDay[i]) < weekIndex(r.ByDay[j])
+			})
+		default:
+			return nil, fmt.Errorf("invalid recurrence: unsupported part %s", name)
+		}
+	}
+	switch {
+	case r.Freq == "":
+		return nil, fmt.Errorf("invalid recurrence: FREQ is missing")
+	case r.Count > 0 && !r.Until.IsZero():
+		return nil, fmt.Errorf("invalid recurrence: COUNT and UNTIL exclude each other")
+	case len(r.ByDay) > 0 && r.Freq != Daily && r.Freq != Weekly:
+		return nil, fmt.Errorf("invalid recurrence: BYDAY is only supported with FREQ=DAILY or FREQ=WEEKLY")
+	}
+	return r, nil
+}
+
+// parseUntil parses an UNTIL date like 20211231, or date time like 20211231T170000Z
+func parseUntil(value string) (time.Time, error) {
+	if len(value) == len("20060102") {
+		date, err := time.Parse("20060102", value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid recurrence: invalid UNTIL %s", value)
+		}
+		return date.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+	until, err := time.Parse("20060102T150405", strings.TrimSuffix(value, "Z"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid recurrence: invalid UNTIL %s", value)
+	}
+	return until, nil
+}
+
+// NextAfter returns the first occurrence following last, which is occurrence number n, that is after t. Occurrences
+// skipped because they are not after t are counted. ok is false once the recurrence ended.
+func (r *Recurrence) NextAfter(last time.Time, n int, t time.Time) (next time.Time, occurrence int, ok bool) {
+	next, occurrence = last, n
+	for {
+		if next = r.next(next); next.IsZero() {
+			return time.Time{}, 0, false
+		}
+		occurrence++
+		if r.Count > 0 && occurrence > r.Count || !r.Until.IsZero() && next.After(r.Until) {
+			return time.Time{}, 0, false
+		}
+		if next.After(t) {
+			return next, occurrence, true
+		}
+	}
+}
+
+// next returns the occurrence following t, which is an occurrence itself, or zero if there is none
+func (r *Recurrence) next(t time.Time) time.Time {
+	switch r.Freq {
+	case Daily:
+		for i := 1; i <= 7; i++ {
+			next := t.AddDate(0, 0, r.Interval*i)
+			if len(r.ByDay) == 0 || r.onDay(next.Weekday()) {
+				return next
+			}
+		}
+		return time.Time{} // the interval never reaches the weekdays
+	case Weekly:
+		if len(r.ByDay) == 0 {
+			return t.AddDate(0, 0, 7*r.Interval)
+		}
+		current := weekIndex(t.Weekday())
+		for _, day := range r.ByDay {
+			if weekIndex(day) > current {
+				return t.AddDate(0, 0, weekIndex(day)-current)
+			}
+		}
+		// the first weekday of the next week of the recurrence
+		return t.AddDate(0, 0, 7*r.Interval-current+weekIndex(r.ByDay[0]))
+	case Monthly, Yearly:
+		// dates missing in a period, like February 30, are skipped
+		for i := 1; i <= maxSkipped; i++ {
+			months := r.Interval * i
+			if r.Freq == Yearly {
+				months *= 12
+			}
+			next := time.Date(t.Year(), t.Month()+time.Month(months), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+			if next.Day() == t.Day() {
+				return next
+			}
+		}
+	}
+	return time.Time{}
+}
+
+func (r *Recurrence) onDay(weekday time.Weekday) bool {
+	for _, day := range r.ByDay {
+		if day == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// weekIndex numbers the weekdays from Monday
+func weekIndex(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
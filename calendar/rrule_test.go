@@ -0,0 +1,119 @@
+package calendar_test
+
+import (
+	"github.com/nadilas/todo/calendar"
+	. "github.com/nadilas/todo/workflows/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	var recurrence *calendar.Recurrence
+	var err error
+
+	Scenario(t, "every part is parsed",
+		When(parsedRecurrence(&recurrence, &err, "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TH,mo;COUNT=5")),
+		Then(func(t *testing.T) {
+			require.NoError(t, err)
+			assert.Equal(t, &calendar.Recurrence{
+				Freq:     calendar.Weekly,
+				Interval: 2,
+				ByDay:    []time.Weekday{time.Monday, time.Thursday},
+				Count:    5,
+			}, recurrence)
+		}),
+	)
+	Scenario(t, "until a date covers the whole day",
+		When(parsedRecurrence(&recurrence, &err, "FREQ=DAILY;UNTIL=20211231")),
+		Then(func(t *testing.T) {
+			require.NoError(t, err)
+			assert.Equal(t, time.Date(2021, 12, 31, 23, 59, 59, 999999999, time.UTC), recurrence.Until)
+		}),
+	)
+	Scenario(t, "missing frequency is rejected",
+		When(parsedRecurrence(&recurrence, &err, "INTERVAL=2")),
+		Then(failedWith(&err, "FREQ is missing")),
+	)
+	Scenario(t, "unsupported parts are rejected",
+		When(parsedRecurrence(&recurrence, &err, "FREQ=MONTHLY;BYMONTHDAY=1")),
+		Then(failedWith(&err, "unsupported part BYMONTHDAY")),
+	)
+	Scenario(t, "ordinal weekdays are rejected",
+		When(parsedRecurrence(&recurrence, &err, "FREQ=WEEKLY;BYDAY=1MO")),
+		Then(failedWith(&err, "unsupported BYDAY 1MO")),
+	)
+	Scenario(t, "count and until together are rejected",
+		When(parsedRecurrence(&recurrence, &err, "FREQ=DAILY;COUNT=2;UNTIL=20211231T000000Z")),
+		Then(failedWith(&err, "COUNT and UNTIL exclude each other")),
+	)
+}
+
+func TestNextAfter(t *testing.T) {
+	monday := time.Date(2021, 8, 2, 9, 0, 0, 0, time.UTC)
+
+	Scenario(t, "daily recurrences skip to the weekdays",
+		Then(nextOccurrence("FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR", time.Date(2021, 8, 6, 9, 0, 0, 0, time.UTC), 1, monday,
+			time.Date(2021, 8, 9, 9, 0, 0, 0, time.UTC), 2)),
+	)
+	Scenario(t, "weekly recurrences continue within the week",
+		Then(nextOccurrence("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE", monday, 1, monday,
+			time.Date(2021, 8, 4, 9, 0, 0, 0, time.UTC), 2)),
+	)
+	Scenario(t, "weekly recurrences skip the weeks of the interval",
+		Then(nextOccurrence("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE", time.Date(2021, 8, 4, 9, 0, 0, 0, time.UTC), 2, monday,
+			time.Date(2021, 8, 16, 9, 0, 0, 0, time.UTC), 3)),
+	)
+	Scenario(t, "monthly recurrences skip months without the day",
+		Then(nextOccurrence("FREQ=MONTHLY", time.Date(2021, 1, 31, 9, 0, 0, 0, time.UTC), 1, time.Time{},
+			time.Date(2021, 3, 31, 9, 0, 0, 0, time.UTC), 2)),
+	)
+	Scenario(t, "yearly recurrences keep leap days",
+		Then(nextOccurrence("FREQ=YEARLY", time.Date(2020, 2, 29, 9, 0, 0, 0, time.UTC), 1, time.Time{},
+			time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC), 2)),
+	)
+	Scenario(t, "missed occurrences are counted",
+		Then(nextOccurrence("FREQ=DAILY", monday, 1, monday.Add(time.Hour*50),
+			time.Date(2021, 8, 5, 9, 0, 0, 0, time.UTC), 4)),
+	)
+	Scenario(t, "days keep their local time across DST",
+		Then(nextOccurrence("FREQ=WEEKLY", time.Date(2021, 10, 25, 9, 0, 0, 0, berlin), 1, time.Time{},
+			time.Date(2021, 11, 1, 9, 0, 0, 0, berlin), 2)),
+	)
+	Scenario(t, "recurrences end after their count",
+		Then(noNextOccurrence("FREQ=DAILY;COUNT=3", monday, 3, monday)),
+	)
+	Scenario(t, "recurrences end after until",
+		Then(noNextOccurrence("FREQ=DAILY;UNTIL=20210803", time.Date(2021, 8, 3, 9, 0, 0, 0, time.UTC), 2, monday)),
+	)
+	Scenario(t, "missed occurrences count towards the end",
+		Then(noNextOccurrence("FREQ=DAILY;COUNT=3", monday, 1, monday.Add(time.Hour*50))),
+	)
+}
+
+func parsedRecurrence(recurrence **calendar.Recurrence, err *error, rule string) func(t *testing.T) {
+	return func(t *testing.T) {
+		*recurrence, *err = calendar.ParseRecurrence(rule)
+	}
+}
+
+func nextOccurrence(rule string, last time.Time, n int, after time.Time, expected time.Time, expectedN int) func(t *testing.T) {
+	return func(t *testing.T) {
+		recurrence, err := calendar.ParseRecurrence(rule)
+		require.NoError(t, err)
+		next, occurrence, ok := recurrence.NextAfter(last, n, after)
+		require.True(t, ok, "the recurrence should not end")
+		assert.True(t, expected.Equal(next), "expected %s, got %s", expected, next)
+		assert.Equal(t, expectedN, occurrence)
+	}
+}
+
+func noNextOccurrence(rule string, last time.Time, n int, after time.Time) func(t *testing.T) {
+	return func(t *testing.T) {
+		recurrence, err := calendar.ParseRecurrence(rule)
+		require.NoError(t, err)
+		next, _, ok := recurrence.NextAfter(last, n, after)
+		assert.False(t, ok, "the recurrence should end, got %s", next)
+	}
+}
@@ -0,0 +1,605 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/nadilas/todo/todopb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	"strings"
+	"time"
+)
+
+const usage = `usage: todo [flags] <command> [args]
+
+commands:
+  add <description>                       add a new todo
+      [--parent <uuid>]                   as a subtask of another todo
+      [--complete-with-subtasks]          completed once every one of its subtasks is
+  list [--all | --overdue | --blocked]    list pending (all, overdue, or blocked) todos
+       [--tree]                           or all todos nested under their parents
+       [--priority high,urgent]           having any of the priorities
+       [--tag billing] [--selector ...]   having every tag and matching a label selector e.g. team=payments
+  done <uuid>                             complete a todo
+  undo <uuid>                             reopen a completed todo
+  rm <uuid>                               delete a todo
+  remind <uuid> [--every 24h] [--at ...]  remind about a todo every duration and/or at an RFC3339 time
+         [--cron "0 9 * * 1-5"]           or on a cron schedule
+         [--timezone Europe/Berlin]       evaluated in an IANA timezone
+  snooze <uuid> [--for 2h] [--until ...]  push the next reminder by a duration or to an RFC3339 time
+  digest [--window 24h] [--off]           merge the reminders due within a window into one email
+  due <uuid> [--at ...] [--off]           set the RFC3339 time a todo is due at, or clear it
+  tag <uuid> [--priority high]            set the priority of a todo
+         [--tag billing] [--label k=v]    add tags and set labels, an empty value removes a label
+  block <uuid> [--by <uuid>] [--off]      set the todos to complete before a todo can start, or clear them
+  repeat <uuid> [--rule ...] [--off]      add the next occurrence of a todo with a due date on completion, by
+                                          an RRULE e.g. "FREQ=WEEKLY;BYDAY=MO", or stop repeating it
+  assign <uuid> <user>                    remind another user known to the directory about a todo
+  watch <uuid> [--user <user>] [--off]    copy users on the reminders about a todo, or stop copying them
+  comment <uuid> <text>                   add a comment to the conversation about a todo
+  history <uuid>                          list the comments and the changes of a todo
+`
+
+// tasklistClient is the part of the TodoService the cli is built on
+type tasklistClient interface {
+	AddTodo(ctx context.Context, request *todopb.AddTodoRequest) (*todopb.AddTodoResponse, error)
+	UpdateTodo(ctx context.Context, request *todopb.UpdateTodoRequest) (*todopb.UpdateTodoResponse, error)
+	DeleteTodo(ctx context.Context, request *todopb.DeleteTodoRequest) (*todopb.DeleteTodoResponse, error)
+	SnoozeTodo(ctx context.Context, request *todopb.SnoozeTodoRequest) (*todopb.SnoozeTodoResponse, error)
+	ReassignTodo(ctx context.Context, request *todopb.ReassignTodoRequest) (*todopb.ReassignTodoResponse, error)
+	AddComment(ctx context.Context, request *todopb.AddCommentRequest) (*todopb.AddCommentResponse, error)
+	GetTodoHistory(ctx context.Context, request *todopb.GetTodoHistoryRequest) (*todopb.TodoHistory, error)
+	SetDigest(ctx context.Context, request *todopb.SetDigestRequest) (*todopb.SetDigestResponse, error)
+	ListPendingTodos(ctx context.Context, request *todopb.ListTodosRequest) (*todopb.ListTodosResponse, error)
+	ListAllTodos(ctx context.Context, request *todopb.ListTodosRequest) (*todopb.ListTodosResponse, error)
+	ListOverdueTodos(ctx context.Context, request *todopb.ListTodosRequest) (*todopb.ListTodosResponse, error)
+	ListBlockedTodos(ctx context.Context, request *todopb.ListTodosRequest) (*todopb.ListTodosResponse, error)
+	FilterTodos(ctx context.Context, request *todopb.FilterTodosRequest) (*todopb.ListTodosResponse, error)
+	ListTodoTree(ctx context.Context, request *todopb.ListTodosRequest) (*todopb.ListTodosResponse, error)
+}
+
+type cli struct {
+	client tasklistClient
+	user   string
+	output string
+	out    io.Writer
+	now    func() time.Time
+}
+
+// run executes the command given in args
+func (c *cli) run(ctx context.Context, args []string) error {
+	if c.output != outputTable && c.output != outputJson {
+		return fmt.Errorf("unknown output format: %s", c.output)
+	}
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	command, args := args[0], args[1:]
+	switch command {
+	case "add":
+		return c.add(ctx, args)
+	case "list":
+		return c.list(ctx, args)
+	case "done":
+		return c.complete(ctx, args, true)
+	case "undo":
+		return c.complete(ctx, args, false)
+	case "rm":
+		return c.remove(ctx, args)
+	case "remind":
+		return c.remind(ctx, args)
+	case "snooze":
+		return c.snooze(ctx, args)
+	case "digest":
+		return c.digest(ctx, args)
+	case "due":
+		return c.due(ctx, args)
+	case "tag":
+		return c.tag(ctx, args)
+	case "block":
+		return c.block(ctx, args)
+	case "repeat":
+		return c.repeat(ctx, args)
+	case "assign":
+		return c.assign(ctx, args)
+	case "watch":
+		return c.watch(ctx, args)
+	case "comment":
+		return c.comment(ctx, args)
+	case "history":
+		return c.history(ctx, args)
+	default:
+		return fmt.Errorf("unknown command: %s\n%s", command, usage)
+	}
+}
+
+func (c *cli) add(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	parent := fs.String("parent", "", "the uuid of the todo to add a subtask to")
+	completeWithSubtasks := fs.Bool("complete-with-subtasks", false, "complete the todo once every one of its subtasks is")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	description := strings.TrimSpace(strings.Join(positional, " "))
+	if description == "" {
+		return errors.New("usage: todo add <description> [--parent <uuid>] [--complete-with-subtasks]")
+	}
+	resp, err := c.client.AddTodo(ctx, &todopb.AddTodoRequest{
+		Item: &todopb.TodoItem{
+			Uuid:                 uuid.New().String(),
+			Description:          description,
+			CreatedBy:            c.user,
+			CreatedAt:            timestamppb.New(c.now()),
+			ParentUuid:           *parent,
+			CompleteWithSubtasks: *completeWithSubtasks,
+		},
+		Owner: c.user,
+	})
+	if err != nil {
+		return err
+	}
+	return c.print(resp.Item)
+}
+
+func (c *cli) list(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	all := fs.Bool("all", false, "list completed todos too")
+	overdue := fs.Bool("overdue", false, "list only the todos whose due date has passed")
+	blocked := fs.Bool("blocked", false, "list only the todos waiting for other todos")
+	tree := fs.Bool("tree", false, "list every todo with its subtasks nested")
+	priorities := fs.String("priority", "", "list only the todos with any of the comma separated priorities")
+	var tags stringList
+	fs.Var(&tags, "tag", "list only the todos with the tag, may be repeated")
+	selector := fs.String("selector", "", "list only the todos matching the label selector, e.g. team=payments")
+	if _, err := parseInterspersed(fs, args); err != nil {
+		return err
+	}
+	filtered := *priorities != "" || len(tags) > 0 || *selector != ""
+	views := 0
+	for _, view := range []bool{*all, *overdue, *blocked, *tree} {
+		if view {
+			views++
+		}
+	}
+	if views > 1 || filtered && (*overdue || *blocked || *tree) {
+		return errors.New("usage: todo list [--all | --overdue | --blocked | --tree] [--priority high] [--tag billing] [--selector team=payments]")
+	}
+
+	if filtered {
+		filter := &todopb.TodoFilter{
+			Tags:             tags,
+			LabelSelector:    *selector,
+			IncludeCompleted: *all,
+		}
+		if *priorities != "" {
+			for _, name := range strings.Split(*priorities, ",") {
+				priority, err := parsePriority(name)
+				if err != nil {
+					return err
+				}
+				filter.Priorities = append(filter.Priorities, priority)
+			}
+		}
+		resp, err := c.client.FilterTodos(ctx, &todopb.FilterTodosRequest{
+			Owner:  c.user,
+			Filter: filter,
+		})
+		if err != nil {
+			return err
+		}
+		return c.print(resp.Items...)
+	}
+
+	list := c.client.ListPendingTodos
+	if *all {
+		list = c.client.ListAllTodos
+	}
+	if *overdue {
+		list = c.client.ListOverdueTodos
+	}
+	if *blocked {
+		list = c.client.ListBlockedTodos
+	}
+	if *tree {
+		list = c.client.ListTodoTree
+	}
+	resp, err := list(ctx, &todopb.ListTodosRequest{
+		Owner: c.user,
+	})
+	if err != nil {
+		return err
+	}
+	return c.print(resp.Items...)
+}
+
+func (c *cli) complete(ctx context.Context, args []string, completed bool) error {
+	if len(args) != 1 {
+		return errors.New("usage: todo done|undo <uuid>")
+	}
+	item := &todopb.TodoItem{
+		Uuid: args[0],
+	}
+	if completed {
+		item.CompletedAt = timestamppb.New(c.now())
+		item.CompletedBy = c.user
+	}
+	return c.update(ctx, item, "completedAt", "completedBy")
+}
+
+func (c *cli) remove(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: todo rm <uuid>")
+	}
+	_, err := c.client.DeleteTodo(ctx, &todopb.DeleteTodoRequest{
+		Uuid:  args[0],
+		Owner: c.user,
+	})
+	return err
+}
+
+func (c *cli) remind(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("remind", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	every := fs.Duration("every", 0, "remind every duration, e.g. 24h")
+	at := fs.String("at", "", "remind at (or starting at) an RFC3339 time")
+	schedule := fs.String("cron", "", "remind on a cron schedule, e.g. \"0 9 * * 1-5\" or @daily")
+	timezone := fs.String("timezone", "", "IANA timezone the cron schedule and daily reminders are evaluated in")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || (*every == 0 && *at == "" && *schedule == "") {
+		return errors.New("usage: todo remind <uuid> [--every 24h] [--at 2006-01-02T15:04:05Z] [--cron \"0 9 * * 1-5\"]")
+	}
+
+	reminder := &todopb.Reminder{
+		Cron:     *schedule,
+		Timezone: *timezone,
+	}
+	if *every > 0 {
+		reminder.Every = every.String()
+	}
+	if *at != "" {
+		t, err := time.Parse(time.RFC3339, *at)
+		if err != nil {
+			return fmt.Errorf("invalid --at: %w", err)
+		}
+		reminder.At = timestamppb.New(t)
+	}
+
+	return c.update(ctx, &todopb.TodoItem{
+		Uuid:     positional[0],
+		Reminder: reminder,
+	}, "reminder")
+}
+
+func (c *cli) snooze(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("snooze", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	duration := fs.Duration("for", 0, "push the next reminder by a duration, e.g. 2h")
+	until := fs.String("until", "", "push the next reminder to an RFC3339 time")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || (*duration == 0) == (*until == "") {
+		return errors.New("usage: todo snooze <uuid> --for 2h | --until 2006-01-02T15:04:05Z")
+	}
+
+	request := &todopb.SnoozeTodoRequest{
+		Uuid:  positional[0],
+		Owner: c.user,
+	}
+	if *duration != 0 {
+		request.Duration = duration.String()
+	}
+	if *until != "" {
+		t, err := time.Parse(time.RFC3339, *until)
+		if err != nil {
+			return fmt.Errorf("invalid --until: %w", err)
+		}
+		request.Until = timestamppb.New(t)
+	}
+
+	resp, err := c.client.SnoozeTodo(ctx, request)
+	if err != nil {
+		return err
+	}
+	return c.print(resp.Item)
+}
+
+func (c *cli) digest(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("digest", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	window := fs.Duration("window", 0, "merge the reminders due within the window, e.g. 24h")
+	off := fs.Bool("off", false, "send every reminder on its own")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 || (*window == 0) == !*off {
+		return errors.New("usage: todo digest --window 24h | --off")
+	}
+
+	request := &todopb.SetDigestRequest{
+		Owner: c.user,
+	}
+	if !*off {
+		request.Digest = &todopb.Digest{
+			Window: window.String(),
+		}
+	}
+	resp, err := c.client.SetDigest(ctx, request)
+	if err != nil {
+		return err
+	}
+	if resp.Digest == nil {
+		_, err = fmt.Fprintln(c.out, "digest off")
+		return err
+	}
+	_, err = fmt.Fprintln(c.out, "digest every "+resp.Digest.Window)
+	return err
+}
+
+func (c *cli) due(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("due", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	at := fs.String("at", "", "the RFC3339 time the todo is due at")
+	off := fs.Bool("off", false, "clear the due date")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || (*at == "") == !*off {
+		return errors.New("usage: todo due <uuid> --at 2006-01-02T15:04:05Z | --off")
+	}
+
+	var dueAt *timestamppb.Timestamp
+	if *at != "" {
+		t, err := time.Parse(time.RFC3339, *at)
+		if err != nil {
+			return fmt.Errorf("invalid --at: %w", err)
+		}
+		dueAt = timestamppb.New(t)
+	}
+
+	return c.update(ctx, &todopb.TodoItem{
+		Uuid:  positional[0],
+		DueAt: dueAt,
+	}, "dueAt")
+}
+
+func (c *cli) tag(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("tag", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	priority := fs.String("priority", "", "the priority: none, low, medium, high or urgent")
+	var tags, labels stringList
+	fs.Var(&tags, "tag", "add a tag, may be repeated")
+	fs.Var(&labels, "label", "set a label key=value, an empty value removes it, may be repeated")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || (*priority == "" && len(tags) == 0 && len(labels) == 0) {
+		return errors.New("usage: todo tag <uuid> [--priority high] [--tag billing] [--label team=payments]")
+	}
+
+	item, err := c.find(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	if *priority != "" {
+		if item.Priority, err = parsePriority(*priority); err != nil {
+			return err
+		}
+	}
+	for _, tag := range tags {
+		if !contains(item.Tags, tag) {
+			item.Tags = append(item.Tags, tag)
+		}
+	}
+	for _, label := range labels {
+		kv := strings.SplitN(label, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return fmt.Errorf("invalid --label %q, expected key=value", label)
+		}
+		if kv[1] == "" {
+			delete(item.Labels, kv[0])
+			continue
+		}
+		if item.Labels == nil {
+			item.Labels = map[string]string{}
+		}
+		item.Labels[kv[0]] = kv[1]
+	}
+	return c.update(ctx, item, "priority", "tags", "labels")
+}
+
+func (c *cli) block(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("block", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	var by stringList
+	fs.Var(&by, "by", "the uuid of a todo to complete first, may be repeated")
+	off := fs.Bool("off", false, "clear the blockers")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || (len(by) == 0) == !*off {
+		return errors.New("usage: todo block <uuid> --by <uuid> [--by <uuid>] | --off")
+	}
+
+	return c.update(ctx, &todopb.TodoItem{
+		Uuid:      positional[0],
+		BlockedBy: by,
+	}, "blockedBy")
+}
+
+func (c *cli) repeat(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("repeat", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	rule := fs.String("rule", "", "the RRULE of FREQ, INTERVAL, BYDAY, COUNT and UNTIL parts, e.g. FREQ=WEEKLY;BYDAY=MO")
+	off := fs.Bool("off", false, "stop repeating the todo")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || (*rule == "") == !*off {
+		return errors.New("usage: todo repeat <uuid> --rule \"FREQ=WEEKLY;BYDAY=MO\" | --off")
+	}
+
+	return c.update(ctx, &todopb.TodoItem{
+		Uuid:       positional[0],
+		Recurrence: *rule,
+	}, "recurrence")
+}
+
+func (c *cli) assign(ctx context.Context, args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: todo assign <uuid> <user>")
+	}
+	resp, err := c.client.ReassignTodo(ctx, &todopb.ReassignTodoRequest{
+		Uuid:     args[0],
+		Owner:    c.user,
+		Assignee: args[1],
+	})
+	if err != nil {
+		return err
+	}
+	return c.print(resp.Item)
+}
+
+func (c *cli) watch(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	var users stringList
+	fs.Var(&users, "user", "a user copied on the reminders, may be repeated")
+	off := fs.Bool("off", false, "clear the watchers")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || (len(users) == 0) == !*off {
+		return errors.New("usage: todo watch <uuid> --user <user> [--user <user>] | --off")
+	}
+
+	return c.update(ctx, &todopb.TodoItem{
+		Uuid:     positional[0],
+		Watchers: users,
+	}, "watchers")
+}
+
+func (c *cli) comment(ctx context.Context, args []string) error {
+	if len(args) < 2 || strings.TrimSpace(strings.Join(args[1:], " ")) == "" {
+		return errors.New("usage: todo comment <uuid> <text>")
+	}
+	resp, err := c.client.AddComment(ctx, &todopb.AddCommentRequest{
+		Uuid:  args[0],
+		Owner: c.user,
+		Text:  strings.TrimSpace(strings.Join(args[1:], " ")),
+	})
+	if err != nil {
+		return err
+	}
+	return c.printHistory(&todopb.TodoHistory{
+		Comments: []*todopb.Comment{resp.Comment},
+	})
+}
+
+func (c *cli) history(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: todo history <uuid>")
+	}
+	history, err := c.client.GetTodoHistory(ctx, &todopb.GetTodoHistoryRequest{
+		Uuid:  args[0],
+		Owner: c.user,
+	})
+	if err != nil {
+		return err
+	}
+	return c.printHistory(history)
+}
+
+// update sets the fields at the paths of the todo to the ones of item. An item read from the Tasklist is only
+// updated at its revision, so changes made in the meantime are not overwritten.
+func (c *cli) update(ctx context.Context, item *todopb.TodoItem, paths ...string) error {
+	resp, err := c.client.UpdateTodo(ctx, &todopb.UpdateTodoRequest{
+		Item:             item,
+		Owner:            c.user,
+		UpdateMask:       &fieldmaskpb.FieldMask{Paths: paths},
+		ExpectedRevision: item.Revision,
+	})
+	if err != nil {
+		return err
+	}
+	return c.print(resp.Item)
+}
+
+// find returns the todo with the given uuid
+func (c *cli) find(ctx context.Context, id string) (*todopb.TodoItem, error) {
+	resp, err := c.client.ListAllTodos(ctx, &todopb.ListTodosRequest{
+		Owner: c.user,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range resp.Items {
+		if item.Uuid == id {
+			return item, nil
+		}
+	}
+	return nil, fmt.Errorf("task not found: %s", id)
+}
+
+// parsePriority parses a priority name like high, ignoring its case
+func parsePriority(name string) (todopb.TodoItem_Priority, error) {
+	priority, ok := todopb.TodoItem_Priority_value[strings.ToUpper(strings.TrimSpace(name))]
+	if !ok {
+		return todopb.TodoItem_NONE, fmt.Errorf("unknown priority: %s", name)
+	}
+	return todopb.TodoItem_Priority(priority), nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// stringList is a flag which may be repeated
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// parseInterspersed parses flags which may follow positional arguments and returns the positional ones
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
@@ -0,0 +1,633 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/nadilas/todo/todopb"
+	. "github.com/nadilas/todo/workflows/testutils"
+	"github.com/nadilas/todo/workflows/todo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strings"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2021, 8, 4, 12, 0, 0, 0, time.UTC)
+
+func TestCli(t *testing.T) {
+	var tasklist *fakeTasklist
+	var out *bytes.Buffer
+	threeDaysOld := &todopb.TodoItem{
+		Uuid:        "t1",
+		Description: "some task",
+		CreatedBy:   "user1",
+		CreatedAt:   timestamppb.New(now.Add(-time.Hour * 72)),
+	}
+	completed := &todopb.TodoItem{
+		Uuid:        "t2",
+		Description: "some task 2",
+		CreatedBy:   "user1",
+		CreatedAt:   timestamppb.New(now.Add(-time.Hour * 72)),
+		CompletedAt: timestamppb.New(now.Add(-time.Hour * 2)),
+		CompletedBy: "user1",
+	}
+
+	Scenario(t, "add creates a pending todo",
+		Given(aTasklist(&tasklist)),
+		When(executed(&tasklist, &out, "table", "add", "buy", "milk")),
+		Then(func(t *testing.T) {
+			require.Len(t, tasklist.items, 1)
+			item := tasklist.items[0]
+			assert.Equal(t, "buy milk", item.Description)
+			assert.Equal(t, "user1", item.CreatedBy)
+			assert.NotEmpty(t, item.Uuid)
+			assert.Contains(t, out.String(), "buy milk")
+		}),
+	)
+	Scenario(t, "add with a parent creates a subtask",
+		Given(aTasklist(&tasklist, threeDaysOld)),
+		When(executed(&tasklist, &out, "table", "add", "--parent", "t1", "--complete-with-subtasks", "call", "the", "bank")),
+		Then(func(t *testing.T) {
+			require.Len(t, tasklist.items, 2)
+			item := tasklist.items[1]
+			assert.Equal(t, "call the bank", item.Description)
+			assert.Equal(t, "t1", item.ParentUuid)
+			assert.True(t, item.CompleteWithSubtasks)
+		}),
+	)
+	Scenario(t, "list --tree indents subtasks under their parent",
+		Given(aTasklist(&tasklist, threeDaysOld, &todopb.TodoItem{
+			Uuid:        "t3",
+			Description: "some subtask",
+			ParentUuid:  "t1",
+		}, &todopb.TodoItem{
+			Uuid:        "t4",
+			Description: "some nested subtask",
+			ParentUuid:  "t3",
+		})),
+		When(executed(&tasklist, &out, "table", "list", "--tree")),
+		Then(func(t *testing.T) {
+			lines := strings.Split(out.String(), "\n")
+			require.True(t, len(lines) > 3)
+			assert.Contains(t, lines[1], "  some task ")
+			assert.Contains(t, lines[2], "    some subtask ")
+			assert.Contains(t, lines[3], "      some nested subtask ")
+		}),
+	)
+	Scenario(t, "list --tree can't be combined with filters",
+		Given(aTasklist(&tasklist, threeDaysOld)),
+		Then(failed(&tasklist, "usage: todo list", "list", "--tree", "--tag", "billing")),
+	)
+	Scenario(t, "block sets the todos to complete first",
+		Given(aTasklist(&tasklist, threeDaysOld, completed)),
+		When(executed(&tasklist, &out, "table", "block", "t1", "--by", "t2", "--by", "t3")),
+		Then(func(t *testing.T) {
+			assert.Equal(t, []string{"t2", "t3"}, tasklist.items[0].BlockedBy)
+		}),
+	)
+	Scenario(t, "block --off clears the blockers",
+		Given(aTasklist(&tasklist, &todopb.TodoItem{Uuid: "t1", Description: "some task", BlockedBy: []string{"t2"}})),
+		When(executed(&tasklist, &out, "table", "block", "t1", "--off")),
+		Then(func(t *testing.T) {
+			assert.Empty(t, tasklist.items[0].BlockedBy)
+		}),
+	)
+	Scenario(t, "block needs blockers or --off",
+		Given(aTasklist(&tasklist, threeDaysOld)),
+		Then(failed(&tasklist, "usage: todo block", "block", "t1")),
+	)
+	Scenario(t, "list --blocked lists the todos waiting for open todos",
+		Given(aTasklist(&tasklist, threeDaysOld, completed, &todopb.TodoItem{
+			Uuid:        "t3",
+			Description: "some blocked task",
+			BlockedBy:   []string{"t1"},
+		}, &todopb.TodoItem{
+			Uuid:        "t4",
+			Description: "some unblocked task",
+			BlockedBy:   []string{"t2"},
+		})),
+		When(executed(&tasklist, &out, "table", "list", "--blocked")),
+		Then(func(t *testing.T) {
+			assert.Contains(t, out.String(), "some blocked task")
+			assert.NotContains(t, out.String(), "some unblocked task")
+		}),
+	)
+	Scenario(t, "repeat sets the recurrence",
+		Given(aTasklist(&tasklist, threeDaysOld)),
+		When(executed(&tasklist, &out, "table", "repeat", "t1", "--rule", "FREQ=WEEKLY;BYDAY=MO")),
+		Then(func(t *testing.T) {
+			assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO", tasklist.items[0].Recurrence)
+		}),
+	)
+	Scenario(t, "repeat needs a rule or --off",
+		Given(aTasklist(&tasklist, threeDaysOld)),
+		Then(failed(&tasklist, "usage: todo repeat", "repeat", "t1", "--rule", "FREQ=DAILY", "--off")),
+	)
+	Scenario(t, "assign reassigns the todo",
+		Given(aTasklist(&tasklist, threeDaysOld)),
+		When(executed(&tasklist, &out, "table", "assign", "t1", "jdoe")),
+		Then(func(t *testing.T) {
+			assert.Equal(t, "jdoe", tasklist.items[0].Assignee)
+		}),
+	)
+	Scenario(t, "assign needs a user",
+		Given(aTasklist(&tasklist, threeDaysOld)),
+		Then(failed(&tasklist, "usage: todo assign", "assign", "t1")),
+	)
+	Scenario(t, "watch sets the watchers",
+		Given(aTasklist(&tasklist, threeDaysOld)),
+		When(executed(&tasklist, &out, "table", "watch", "t1", "--user", "jdoe", "--user", "asmith")),
+		Then(func(t *testing.T) {
+			assert.Equal(t, []string{"jdoe", "asmith"}, tasklist.items[0].Watchers)
+		}),
+	)
+	Scenario(t, "comment adds a comment by the user",
+		Given(aTasklist(&tasklist, threeDaysOld)),
+		When(executed(&tasklist, &out, "table", "comment", "t1", "waiting", "for", "the", "invoice")),
+		Then(func(t *testing.T) {
+			if assert.Len(t, tasklist.items[0].Comments, 1) {
+				assert.Equal(t, "user1", tasklist.items[0].Comments[0].Author)
+				assert.Equal(t, "waiting for the invoice", tasklist.items[0].Comments[0].Text)
+			}
+			assert.Contains(t, out.String(), "commented: waiting for the invoice")
+		}),
+	)
+	Scenario(t, "comment needs a text",
+		Given(aTasklist(&tasklist, threeDaysOld)),
+		Then(failed(&tasklist, "usage: todo comment", "comment", "t1")),
+	)
+	Scenario(t, "history merges the comments and the changes by time",
+		Given(aTasklist(&tasklist, &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			Comments: []*todopb.Comment{
+				{Author: "user2", Text: "on it", At: timestamppb.New(now.Add(-time.Hour * 2))},
+			},
+			AuditTrail: []*todopb.AuditEntry{
+				{At: timestamppb.New(now.Add(-time.Hour * 3)), Actor: "user1", Signal: "add_task"},
+				{At: timestamppb.New(now.Add(-time.Hour)), Actor: "user1", Signal: "update_task", Field: "priority", To: "HIGH"},
+			},
+		})),
+		When(executed(&tasklist, &out, "table", "history", "t1")),
+		Then(func(t *testing.T) {
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			if assert.Len(t, lines, 4) {
+				assert.Contains(t, lines[1], "3 hours ago")
+				assert.Contains(t, lines[1], "added")
+				assert.Contains(t, lines[2], "user2")
+				assert.Contains(t, lines[2], "commented: on it")
+				assert.Contains(t, lines[3], "priority: - -> HIGH")
+			}
+		}),
+	)
+	Scenario(t, "history leaves out the changes of comments and tells about dropped changes",
+		Given(aTasklist(&tasklist, &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			Comments: []*todopb.Comment{
+				{Author: "user2", Text: "on it", At: timestamppb.New(now.Add(-time.Hour * 2))},
+			},
+			AuditTrail: []*todopb.AuditEntry{
+				{At: timestamppb.New(now.Add(-time.Hour * 2)), Actor: "user2", Signal: "add_comment", Field: "comments", To: "on it"},
+			},
+			AuditEntriesDropped: 3,
+		})),
+		When(executed(&tasklist, &out, "table", "history", "t1")),
+		Then(func(t *testing.T) {
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			if assert.Len(t, lines, 3) {
+				assert.Contains(t, lines[1], "commented: on it")
+				assert.Contains(t, lines[2], "3 older changes not kept")
+			}
+		}),
+	)
+	Scenario(t, "watch --off clears the watchers",
+		Given(aTasklist(&tasklist, &todopb.TodoItem{Uuid: "t1", Description: "some task", Watchers: []string{"jdoe"}})),
+		When(executed(&tasklist, &out, "table", "watch", "t1", "--off")),
+		Then(func(t *testing.T) {
+			assert.Empty(t, tasklist.items[0].Watchers)
+		}),
+	)
+	Scenario(t, "list renders the age of todos",
+		Given(aTasklist(&tasklist, threeDaysOld, completed)),
+		When(executed(&tasklist, &out, "table", "list")),
+		Then(func(t *testing.T) {
+			assert.Contains(t, out.String(), "3 days ago")
+			assert.NotContains(t, out.String(), "some task 2")
+		}),
+	)
+	Scenario(t, "list --all renders completed todos",
+		Given(aTasklist(&tasklist, threeDaysOld, completed)),
+		When(executed(&tasklist, &out, "table", "list", "--all")),
+		Then(func(t *testing.T) {
+			assert.Contains(t, out.String(), "some task 2")
+			assert.Contains(t, out.String(), "2 hours ago")
+		}),
+	)
+	Scenario(t, "list as json",
+		Given(aTasklist(&tasklist, threeDaysOld)),
+		When(executed(&tasklist, &out, "json", "list")),
+		Then(func(t *testing.T) {
+			var resp struct {
+				Items []struct {
+					Uuid string `json:"uuid"`
+				} `json:"items"`
+			}
+			require.NoError(t, json.Unmarshal(out.Bytes(), &resp))
+			require.Len(t, resp.Items, 1)
+			assert.Equal(t, "t1", resp.Items[0].Uuid)
+		}),
+	)
+	Scenario(t, "done completes a todo",
+		Given(aTasklist(&tasklist, threeDaysOld)),
+		When(executed(&tasklist, &out, "table", "done", "t1")),
+		Then(func(t *testing.T) {
+			assert.Equal(t, now, tasklist.items[0].CompletedAt.AsTime())
+			assert.Equal(t, "user1", tasklist.items[0].CompletedBy)
+		}),
+	)
+	Scenario(t, "done only updates the completion",
+		Given(aTasklist(&tasklist, &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			Reminder:    &todopb.Reminder{Every: "24h0m0s"},
+		})),
+		When(executed(&tasklist, &out, "table", "done", "t1")),
+		Then(func(t *testing.T) {
+			assert.NotNil(t, tasklist.items[0].CompletedAt)
+			assert.Equal(t, "some task", tasklist.items[0].Description)
+			assert.Equal(t, "24h0m0s", tasklist.items[0].Reminder.GetEvery())
+		}),
+	)
+	Scenario(t, "undo reopens a todo",
+		Given(aTasklist(&tasklist, completed)),
+		When(executed(&tasklist, &out, "table", "undo", "t2")),
+		Then(func(t *testing.T) {
+			assert.Nil(t, tasklist.items[0].CompletedAt)
+			assert.Empty(t, tasklist.items[0].CompletedBy)
+		}),
+	)
+	Scenario(t, "rm deletes a todo",
+		Given(aTasklist(&tasklist, threeDaysOld, completed)),
+		When(executed(&tasklist, &out, "table", "rm", "t1")),
+		Then(func(t *testing.T) {
+			require.Len(t, tasklist.items, 1)
+			assert.Equal(t, "t2", tasklist.items[0].Uuid)
+		}),
+	)
+	Scenario(t, "remind sets up a reminder",
+		Given(aTasklist(&tasklist, threeDaysOld)),
+		When(executed(&tasklist, &out, "table", "remind", "t1", "--every", "24h", "--at", "2021-08-05T09:00:00Z")),
+		Then(func(t *testing.T) {
+			reminder := tasklist.items[0].Reminder
+			require.NotNil(t, reminder)
+			assert.Equal(t, (time.Hour * 24).String(), reminder.Every)
+			assert.Equal(t, time.Date(2021, 8, 5, 9, 0, 0, 0, time.UTC), reminder.At.AsTime())
+		}),
+	)
+	Scenario(t, "remind sets up a cron reminder",
+		Given(aTasklist(&tasklist, threeDaysOld)),
+		When(executed(&tasklist, &out, "table", "remind", "t1", "--cron", "0 9 * * 1-5", "--timezone", "Europe/Berlin")),
+		Then(func(t *testing.T) {
+			require.NotNil(t, tasklist.items[0].Reminder)
+			assert.Equal(t, "0 9 * * 1-5", tasklist.items[0].Reminder.Cron)
+			assert.Equal(t, "Europe/Berlin", tasklist.items[0].Reminder.Timezone)
+			assert.Contains(t, out.String(), "cron 0 9 * * 1-5")
+		}),
+	)
+	Scenario(t, "snooze pushes the next reminder",
+		Given(aTasklist(&tasklist, &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			Reminder:    &todopb.Reminder{Every: "24h0m0s"},
+		})),
+		When(executed(&tasklist, &out, "table", "snooze", "t1", "--for", "2h")),
+		Then(func(t *testing.T) {
+			require.NotNil(t, tasklist.items[0].SnoozedUntil)
+			assert.Equal(t, now.Add(time.Hour*2), tasklist.items[0].SnoozedUntil.AsTime())
+			assert.Contains(t, out.String(), "snoozed until 2021-08-04 14:00")
+		}),
+	)
+	Scenario(t, "snooze requires either --for or --until",
+		Given(aTasklist(&tasklist, threeDaysOld)),
+		Then(failed(&tasklist, "usage: todo snooze", "snooze", "t1", "--for", "2h", "--until", "2021-08-05T09:00:00Z")),
+	)
+	Scenario(t, "digest sets the window",
+		Given(aTasklist(&tasklist, threeDaysOld)),
+		When(executed(&tasklist, &out, "table", "digest", "--window", "24h")),
+		Then(func(t *testing.T) {
+			assert.Equal(t, "24h0m0s", tasklist.digest.GetWindow())
+			assert.Equal(t, "digest every 24h0m0s\n", out.String())
+		}),
+	)
+	Scenario(t, "digest --off turns it off",
+		Given(aTasklist(&tasklist, threeDaysOld)),
+		When(executed(&tasklist, &out, "table", "digest", "--off")),
+		Then(func(t *testing.T) {
+			assert.Nil(t, tasklist.digest)
+			assert.Equal(t, "digest off\n", out.String())
+		}),
+	)
+	Scenario(t, "due sets the due date",
+		Given(aTasklist(&tasklist, threeDaysOld)),
+		When(executed(&tasklist, &out, "table", "due", "t1", "--at", "2021-08-05T09:00:00Z")),
+		Then(func(t *testing.T) {
+			require.NotNil(t, tasklist.items[0].DueAt)
+			assert.Equal(t, time.Date(2021, 8, 5, 9, 0, 0, 0, time.UTC), tasklist.items[0].DueAt.AsTime())
+			assert.Contains(t, out.String(), "due 2021-08-05 09:00")
+		}),
+	)
+	Scenario(t, "list --overdue renders how long todos are overdue",
+		Given(aTasklist(&tasklist, threeDaysOld, &todopb.TodoItem{
+			Uuid:        "t3",
+			Description: "some overdue task",
+			CreatedAt:   timestamppb.New(now.Add(-time.Hour * 72)),
+			DueAt:       timestamppb.New(now.Add(-time.Hour * 48)),
+		})),
+		When(executed(&tasklist, &out, "table", "list", "--overdue")),
+		Then(func(t *testing.T) {
+			assert.Contains(t, out.String(), "2 days overdue")
+			assert.NotContains(t, out.String(), "some task")
+		}),
+	)
+	Scenario(t, "tag sets the priority, tags and labels",
+		Given(aTasklist(&tasklist, &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			Tags:        []string{"billing"},
+			Labels:      map[string]string{"team": "search", "sprint": "12"},
+		})),
+		When(executed(&tasklist, &out, "table", "tag", "t1", "--priority", "High", "--tag", "billing", "--tag", "q3", "--label", "team=payments", "--label", "sprint=")),
+		Then(func(t *testing.T) {
+			item := tasklist.items[0]
+			assert.Equal(t, todopb.TodoItem_HIGH, item.Priority)
+			assert.Equal(t, []string{"billing", "q3"}, item.Tags)
+			assert.Equal(t, map[string]string{"team": "payments"}, item.Labels)
+			assert.Contains(t, out.String(), "high")
+			assert.Contains(t, out.String(), "billing, q3, team=payments")
+		}),
+	)
+	Scenario(t, "tag updates the todo at the revision it was read",
+		Given(aTasklist(&tasklist, &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			Revision:    4,
+		})),
+		When(executed(&tasklist, &out, "table", "tag", "t1", "--tag", "billing")),
+		Then(func(t *testing.T) {
+			assert.Equal(t, int64(5), tasklist.items[0].Revision)
+			assert.Equal(t, []string{"billing"}, tasklist.items[0].Tags)
+		}),
+	)
+	Scenario(t, "tag rejects unknown priorities",
+		Given(aTasklist(&tasklist, threeDaysOld)),
+		Then(failed(&tasklist, "unknown priority: asap", "tag", "t1", "--priority", "asap")),
+	)
+	Scenario(t, "list filters by priority, tag and label",
+		Given(aTasklist(&tasklist, threeDaysOld, &todopb.TodoItem{
+			Uuid:        "t3",
+			Description: "some urgent task",
+			Priority:    todopb.TodoItem_URGENT,
+			Tags:        []string{"billing"},
+			Labels:      map[string]string{"team": "payments"},
+		})),
+		When(executed(&tasklist, &out, "table", "list", "--priority", "high,urgent", "--tag", "billing", "--selector", "team=payments")),
+		Then(func(t *testing.T) {
+			assert.Contains(t, out.String(), "some urgent task")
+			assert.NotContains(t, out.String(), "some task")
+		}),
+	)
+	Scenario(t, "unknown todos are reported",
+		Given(aTasklist(&tasklist, threeDaysOld)),
+		Then(failed(&tasklist, "task not found: t9", "done", "t9")),
+	)
+	Scenario(t, "remind requires a schedule",
+		Given(aTasklist(&tasklist, threeDaysOld)),
+		Then(failed(&tasklist, "usage: todo remind", "remind", "t1")),
+	)
+}
+
+func aTasklist(tasklist **fakeTasklist, items ...*todopb.TodoItem) func(t *testing.T) {
+	return func(t *testing.T) {
+		*tasklist = &fakeTasklist{}
+		for _, item := range items {
+			(*tasklist).items = append((*tasklist).items, proto.Clone(item).(*todopb.TodoItem))
+		}
+	}
+}
+
+func executed(tasklist **fakeTasklist, out **bytes.Buffer, output string, args ...string) func(t *testing.T) {
+	return func(t *testing.T) {
+		*out = &bytes.Buffer{}
+		require.NoError(t, newTestCli(*tasklist, *out, output).run(context.Background(), args))
+	}
+}
+
+func failed(tasklist **fakeTasklist, expectedErrMsg string, args ...string) func(t *testing.T) {
+	return func(t *testing.T) {
+		err := newTestCli(*tasklist, &bytes.Buffer{}, outputTable).run(context.Background(), args)
+		require.Error(t, err)
+		assert.True(t, strings.Contains(err.Error(), expectedErrMsg), "unexpected error: "+err.Error())
+	}
+}
+
+func newTestCli(tasklist *fakeTasklist, out *bytes.Buffer, output string) *cli {
+	return &cli{
+		client: tasklist,
+		user:   "user1",
+		output: output,
+		out:    out,
+		now: func() time.Time {
+			return now
+		},
+	}
+}
+
+// fakeTasklist keeps the todos in memory instead of a Tasklist workflow
+type fakeTasklist struct {
+	items  []*todopb.TodoItem
+	digest *todopb.Digest
+}
+
+func (f *fakeTasklist) AddTodo(ctx context.Context, request *todopb.AddTodoRequest) (*todopb.AddTodoResponse, error) {
+	f.items = append(f.items, request.Item)
+	return &todopb.AddTodoResponse{Item: request.Item}, nil
+}
+
+func (f *fakeTasklist) UpdateTodo(ctx context.Context, request *todopb.UpdateTodoRequest) (*todopb.UpdateTodoResponse, error) {
+	for i, item := range f.items {
+		if item.Uuid == request.Item.Uuid {
+			if request.ExpectedRevision != 0 && request.ExpectedRevision != item.Revision {
+				return nil, fmt.Errorf("revision conflict: todo %s is at revision %d, not %d", item.Uuid, item.Revision, request.ExpectedRevision)
+			}
+			if len(request.UpdateMask.GetPaths()) == 0 {
+				f.items[i] = request.Item
+			}
+			for _, path := range request.UpdateMask.GetPaths() {
+				fd := item.ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(path))
+				if request.Item.ProtoReflect().Has(fd) {
+					item.ProtoReflect().Set(fd, request.Item.ProtoReflect().Get(fd))
+				} else {
+					item.ProtoReflect().Clear(fd)
+				}
+			}
+			f.items[i].Revision = item.Revision + 1
+			return &todopb.UpdateTodoResponse{Item: f.items[i], Revision: f.items[i].Revision}, nil
+		}
+	}
+	return nil, fmt.Errorf("task not found: %s", request.Item.Uuid)
+}
+
+func (f *fakeTasklist) DeleteTodo(ctx context.Context, request *todopb.DeleteTodoRequest) (*todopb.DeleteTodoResponse, error) {
+	for i, item := range f.items {
+		if item.Uuid == request.Uuid {
+			f.items = append(f.items[:i], f.items[i+1:]...)
+			return &todopb.DeleteTodoResponse{}, nil
+		}
+	}
+	return nil, fmt.Errorf("task not found: %s", request.Uuid)
+}
+
+func (f *fakeTasklist) SnoozeTodo(ctx context.Context, request *todopb.SnoozeTodoRequest) (*todopb.SnoozeTodoResponse, error) {
+	for _, item := range f.items {
+		if item.Uuid == request.Uuid {
+			until := request.Until
+			if request.Duration != "" {
+				duration, err := time.ParseDuration(request.Duration)
+				if err != nil {
+					return nil, err
+				}
+				until = timestamppb.New(now.Add(duration))
+			}
+			item.SnoozedUntil = until
+			return &todopb.SnoozeTodoResponse{Item: item}, nil
+		}
+	}
+	return nil, fmt.Errorf("task not found: %s", request.Uuid)
+}
+
+func (f *fakeTasklist) ReassignTodo(ctx context.Context, request *todopb.ReassignTodoRequest) (*todopb.ReassignTodoResponse, error) {
+	for _, item := range f.items {
+		if item.Uuid == request.Uuid {
+			item.Assignee = request.Assignee
+			return &todopb.ReassignTodoResponse{Item: item}, nil
+		}
+	}
+	return nil, fmt.Errorf("task not found: %s", request.Uuid)
+}
+
+func (f *fakeTasklist) AddComment(ctx context.Context, request *todopb.AddCommentRequest) (*todopb.AddCommentResponse, error) {
+	for _, item := range f.items {
+		if item.Uuid == request.Uuid {
+			comment := &todopb.Comment{Author: request.Owner, Text: request.Text, At: timestamppb.New(now)}
+			item.Comments = append(item.Comments, comment)
+			return &todopb.AddCommentResponse{Comment: comment}, nil
+		}
+	}
+	return nil, fmt.Errorf("task not found: %s", request.Uuid)
+}
+
+func (f *fakeTasklist) GetTodoHistory(ctx context.Context, request *todopb.GetTodoHistoryRequest) (*todopb.TodoHistory, error) {
+	for _, item := range f.items {
+		if item.Uuid == request.Uuid {
+			return &todopb.TodoHistory{Comments: item.Comments, AuditTrail: item.AuditTrail, AuditEntriesDropped: item.AuditEntriesDropped}, nil
+		}
+	}
+	return nil, fmt.Errorf("task not found: %s", request.Uuid)
+}
+
+func (f *fakeTasklist) SetDigest(ctx context.Context, request *todopb.SetDigestRequest) (*todopb.SetDigestResponse, error) {
+	f.digest = request.Digest
+	return &todopb.SetDigestResponse{Digest: request.Digest}, nil
+}
+
+func (f *fakeTasklist) ListPendingTodos(ctx context.Context, request *todopb.ListTodosRequest) (*todopb.ListTodosResponse, error) {
+	resp := &todopb.ListTodosResponse{}
+	for _, item := range f.items {
+		if item.CompletedAt == nil {
+			resp.Items = append(resp.Items, proto.Clone(item).(*todopb.TodoItem))
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeTasklist) ListOverdueTodos(ctx context.Context, request *todopb.ListTodosRequest) (*todopb.ListTodosResponse, error) {
+	resp := &todopb.ListTodosResponse{}
+	for _, item := range f.items {
+		if item.CompletedAt == nil && item.DueAt != nil && !item.DueAt.AsTime().After(now) {
+			overdue := proto.Clone(item).(*todopb.TodoItem)
+			overdue.Due = fmt.Sprintf("%d days overdue", int(now.Sub(item.DueAt.AsTime())/(time.Hour*24)))
+			resp.Items = append(resp.Items, overdue)
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeTasklist) FilterTodos(ctx context.Context, request *todopb.FilterTodosRequest) (*todopb.ListTodosResponse, error) {
+	selector, err := todo.ParseLabelSelector(request.Filter.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+	resp := &todopb.ListTodosResponse{}
+	for _, item := range f.items {
+		matched := selector.Matches(item.Labels) && (item.CompletedAt == nil || request.Filter.IncludeCompleted)
+		for _, tag := range request.Filter.Tags {
+			matched = matched && contains(item.Tags, tag)
+		}
+		if len(request.Filter.Priorities) > 0 {
+			prioritized := false
+			for _, priority := range request.Filter.Priorities {
+				prioritized = prioritized || item.Priority == priority
+			}
+			matched = matched && prioritized
+		}
+		if matched {
+			resp.Items = append(resp.Items, proto.Clone(item).(*todopb.TodoItem))
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeTasklist) ListBlockedTodos(ctx context.Context, request *todopb.ListTodosRequest) (*todopb.ListTodosResponse, error) {
+	resp := &todopb.ListTodosResponse{}
+	for _, item := range f.items {
+		for _, blocker := range f.items {
+			if item.CompletedAt == nil && blocker.CompletedAt == nil && contains(item.BlockedBy, blocker.Uuid) {
+				resp.Items = append(resp.Items, item)
+				break
+			}
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeTasklist) ListTodoTree(ctx context.Context, request *todopb.ListTodosRequest) (*todopb.ListTodosResponse, error) {
+	return &todopb.ListTodosResponse{Items: f.subtasks("")}, nil
+}
+
+// subtasks returns copies of the todos with the parent, with their subtasks nested
+func (f *fakeTasklist) subtasks(parent string) []*todopb.TodoItem {
+	var items []*todopb.TodoItem
+	for _, item := range f.items {
+		if item.ParentUuid == parent {
+			node := proto.Clone(item).(*todopb.TodoItem)
+			node.Subtasks = f.subtasks(item.Uuid)
+			items = append(items, node)
+		}
+	}
+	return items
+}
+
+func (f *fakeTasklist) ListAllTodos(ctx context.Context, request *todopb.ListTodosRequest) (*todopb.ListTodosResponse, error) {
+	resp := &todopb.ListTodosResponse{}
+	for _, item := range f.items {
+		resp.Items = append(resp.Items, proto.Clone(item).(*todopb.TodoItem))
+	}
+	return resp, nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/nadilas/todo/config"
+	"github.com/nadilas/todo/server"
+	"go.temporal.io/sdk/client"
+	"os"
+	"time"
+)
+
+func main() {
+	configPath := flag.String("config", os.Getenv("TODO_CONFIG"), "YAML or JSON config file, overridden by TODO_* environment variables")
+	user := flag.String("user", os.Getenv("USER"), "owner of the tasklist")
+	output := flag.String("output", outputTable, "output format: json|table")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage, "\nflags:\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	configProvider, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to load config:", err)
+		os.Exit(1)
+	}
+
+	temporal := configProvider.Sub("temporal")
+	c, err := client.NewClient(client.Options{
+		HostPort:  temporal.GetString("address"),
+		Namespace: temporal.GetString("namespace"),
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to create Temporal client:", err)
+		os.Exit(1)
+	}
+	defer c.Close()
+
+	app := &cli{
+		client: server.NewTodoService(c, configProvider.GetString("task_queue")),
+		user:   *user,
+		output: *output,
+		out:    os.Stdout,
+		now:    time.Now,
+	}
+	if err := app.run(context.Background(), flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.Close()
+		os.Exit(1)
+	}
+}
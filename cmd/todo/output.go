@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"github.com/nadilas/todo/timeago"
+	"github.com/nadilas/todo/todopb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	outputTable = "table"
+	outputJson  = "json"
+)
+
+func (c *cli) print(items ...*todopb.TodoItem) error {
+	if c.output == outputJson {
+		return c.printJson(items)
+	}
+	return c.printTable(items)
+}
+
+func (c *cli) printJson(items []*todopb.TodoItem) error {
+	data, err := protojson.Marshal(&todopb.ListTodosResponse{
+		Items: items,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(c.out, string(data))
+	return err
+}
+
+func (c *cli) printTable(items []*todopb.TodoItem) error {
+	w := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "UUID\tDESCRIPTION\tPRIORITY\tTAGS\tCREATED\tCOMPLETED\tDUE\tREMINDER")
+	c.printRows(w, items, "")
+	return w.Flush()
+}
+
+// printHistory prints the comments and the audit trail merged by time, oldest first. The audit entries of comments are
+// left out, as the comments are printed with their text.
+func (c *cli) printHistory(history *todopb.TodoHistory) error {
+	if c.output == outputJson {
+		data, err := protojson.Marshal(history)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(c.out, string(data))
+		return err
+	}
+	type row struct {
+		at          *timestamppb.Timestamp
+		who, change string
+	}
+	var rows []row
+	for _, comment := range history.Comments {
+		rows = append(rows, row{comment.At, comment.Author, "commented: " + comment.Text})
+	}
+	for _, entry := range history.AuditTrail {
+		if entry.Field == "comments" {
+			continue
+		}
+		rows = append(rows, row{entry.At, entry.Actor, formatChange(entry)})
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].at.AsTime().Before(rows[j].at.AsTime())
+	})
+	w := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "WHEN\tWHO\tCHANGE")
+	for _, r := range rows {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", c.ago(r.at), r.who, r.change)
+	}
+	if history.AuditEntriesDropped > 0 {
+		fmt.Fprintf(w, "\t\t%d older changes not kept\n", history.AuditEntriesDropped)
+	}
+	return w.Flush()
+}
+
+// formatChange renders an audit entry, e.g. "priority: - -> HIGH"
+func formatChange(entry *todopb.AuditEntry) string {
+	if entry.Field == "" {
+		return "added"
+	}
+	from, to := entry.From, entry.To
+	if from == "" {
+		from = "-"
+	}
+	if to == "" {
+		to = "-"
+	}
+	return entry.Field + ": " + from + " -> " + to
+}
+
+// printRows prints a row per item followed by the rows of its subtasks, whose descriptions are indented
+func (c *cli) printRows(w io.Writer, items []*todopb.TodoItem, indent string) {
+	for _, item := range items {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			item.Uuid,
+			indent+item.Description,
+			formatPriority(item.Priority),
+			formatTags(item.Tags, item.Labels),
+			c.ago(item.CreatedAt),
+			c.ago(item.CompletedAt),
+			formatDue(item),
+			formatReminder(item.Reminder, item.SnoozedUntil),
+		)
+		c.printRows(w, item.Subtasks, indent+"  ")
+	}
+}
+
+// ago renders the timestamp relative to now, e.g. "3 days ago"
+func (c *cli) ago(t *timestamppb.Timestamp) string {
+	if t == nil {
+		return "-"
+	}
+	return timeago.English.FormatReference(t.AsTime(), c.now())
+}
+
+func formatPriority(priority todopb.TodoItem_Priority) string {
+	if priority == todopb.TodoItem_NONE {
+		return "-"
+	}
+	return strings.ToLower(priority.String())
+}
+
+// formatTags renders the tags followed by the labels sorted by key
+func formatTags(tags []string, labels map[string]string) string {
+	parts := append([]string{}, tags...)
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		parts = append(parts, key+"="+labels[key])
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatDue renders how the todo is due as told by the Tasklist, or its due date if the todo was not queried
+func formatDue(item *todopb.TodoItem) string {
+	if item.DueAt == nil {
+		return "-"
+	}
+	if item.Due != "" {
+		return item.Due
+	}
+	return "due " + item.DueAt.AsTime().Format("2006-01-02 15:04")
+}
+
+func formatReminder(reminder *todopb.Reminder, snoozedUntil *timestamppb.Timestamp) string {
+	if reminder == nil {
+		return "-"
+	}
+	var parts []string
+	if reminder.At != nil {
+		parts = append(parts, "at "+reminder.At.AsTime().Format("2006-01-02 15:04"))
+	}
+	if reminder.Every != "" {
+		parts = append(parts, "every "+reminder.Every)
+	}
+	if reminder.Cron != "" {
+		parts = append(parts, "cron "+reminder.Cron)
+	}
+	if reminder.Timezone != "" {
+		parts = append(parts, reminder.Timezone)
+	}
+	if snoozedUntil != nil {
+		parts = append(parts, "snoozed until "+snoozedUntil.AsTime().Format("2006-01-02 15:04"))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"github.com/nadilas/todo/calendar"
+	"github.com/nadilas/todo/config"
+	"github.com/nadilas/todo/services"
+	"github.com/nadilas/todo/templates"
+	"github.com/nadilas/todo/workflows"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+	"log"
+	"os"
+	"strings"
+	// reminders are computed in the zone of the todo, which must not depend on the zoneinfo of the worker host
+	_ "time/tzdata"
+)
+
+func main() {
+	configPath := flag.String("config", os.Getenv("TODO_CONFIG"), "YAML or JSON config file, overridden by TODO_* environment variables")
+	flag.Parse()
+
+	configProvider, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalln("Unable to load config:", err)
+	}
+
+	if _, err := templates.Load(configProvider.GetString("templates.dir")); err != nil {
+		log.Fatalln("Invalid templates:", err)
+	}
+
+	if _, err := calendar.PolicyFromConfig(configProvider.Sub("delivery")); err != nil {
+		log.Fatalln("Invalid delivery policy:", err)
+	}
+
+	enabledServices := splitServices(configProvider.GetString("services"))
+	if err := workflows.ValidateServices(enabledServices...); err != nil {
+		log.Fatalln("Invalid services:", err)
+	}
+
+	temporal := configProvider.Sub("temporal")
+	c, err := client.NewClient(client.Options{
+		HostPort:  temporal.GetString("address"),
+		Namespace: temporal.GetString("namespace"),
+	})
+	if err != nil {
+		log.Fatalln("Unable to create Temporal client:", err)
+	}
+	defer c.Close()
+
+	taskQueue := configProvider.GetString("task_queue")
+	w := worker.New(c, taskQueue, worker.Options{})
+	workflows.Register(w, configProvider, services.ServiceContainer(configProvider), enabledServices...)
+
+	log.Println("Starting worker", "taskQueue", taskQueue, "services", enabledServices)
+	// Run stops the worker gracefully on SIGINT and SIGTERM
+	if err := w.Run(worker.InterruptCh()); err != nil {
+		log.Fatalln("Unable to start worker:", err)
+	}
+	log.Println("Worker stopped")
+}
+
+func splitServices(serviceList string) []string {
+	var enabled []string
+	for _, service := range strings.Split(serviceList, ",") {
+		if service = strings.TrimSpace(service); service != "" {
+			enabled = append(enabled, service)
+		}
+	}
+	return enabled
+}
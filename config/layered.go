@@ -0,0 +1,193 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EnvPrefix prefixes the environment variables overriding configuration keys
+const EnvPrefix = "TODO_"
+
+// Defaults holds the value of every known key. The type of a default is the type a configured value must parse as.
+var Defaults = map[string]interface{}{
+	"temporal.address":   "localhost:7233",
+	"temporal.namespace": "default",
+	"task_queue":         "todo",
+	"services":           "tasklist,signalproxy",
+
+	"mail.sender":  "tool@domain.com",
+	"mail.service": "smtp",
+
+	// the directory holding the reminder templates, the embedded defaults are used if empty
+	"templates.dir": "",
+
+	"smtp.address":              "localhost:25",
+	"smtp.helo":                 "localhost",
+	"smtp.start_tls":            false,
+	"smtp.insecure_skip_verify": false,
+	"smtp.username":             "",
+	"smtp.password":             "",
+	"smtp.timeout":              time.Second * 30,
+
+	"active_directory.url":                  "ldap://localhost:389",
+	"active_directory.start_tls":            false,
+	"active_directory.insecure_skip_verify": false,
+	"active_directory.bind_dn":              "",
+	"active_directory.bind_password":        "",
+	"active_directory.base_dn":              "",
+	"active_directory.domain":               "",
+	"active_directory.timeout":              time.Second * 10,
+
+	// when reminders are delivered, overridden per user under delivery.users.<owner>
+	"delivery.timezone":     "UTC",
+	"delivery.quiet_hours":  "",
+	"delivery.working_days": "",
+	"delivery.holidays":     "",
+	"delivery.holidays_ics": "",
+
+	// the group notified by escalation levels targeting a group without naming one
+	"escalation.group": "",
+
+	"reminder.schedule_to_close_timeout": time.Hour * 2,
+	"reminder.start_to_close_timeout":    time.Minute * 1,
+	// how long before its due date the assignee of a todo is notified that it is due soon
+	"reminder.due_soon_before": time.Hour * 24,
+	// how long the assignee of a todo is looked up in the directory before the assignment is rejected
+	"reminder.assignee_lookup_timeout": time.Second * 30,
+}
+
+type layered struct {
+	prefix string
+	values map[string]string
+}
+
+// Load layers the Defaults, the YAML or JSON file at path (skipped if path is empty) and the TODO_* environment
+// variables, in increasing precedence.
+func Load(path string) (Provider, error) {
+	return New(Defaults, path, os.LookupEnv)
+}
+
+// New layers the defaults, the file at path and the environment looked up with lookupEnv. The environment variable
+// of a key is EnvPrefix followed by the upper cased key with dots replaced by underscores, e.g. TODO_MAIL_SENDER.
+// Values which do not parse as the type of their default are returned as a single error.
+func New(defaults map[string]interface{}, path string, lookupEnv func(key string) (string, bool)) (Provider, error) {
+	values := map[string]string{}
+	for key, value := range defaults {
+		values[key] = format(value)
+	}
+
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading config: %w", err)
+		}
+		var file map[string]interface{}
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("parsing config %s: %w", path, err)
+		}
+		flatten("", file, values)
+	}
+
+	for key := range values {
+		if value, ok := lookupEnv(EnvKey(key)); ok {
+			values[key] = value
+		}
+	}
+
+	if err := validate(defaults, values); err != nil {
+		return nil, err
+	}
+	return &layered{values: values}, nil
+}
+
+// EnvKey returns the environment variable overriding the key
+func EnvKey(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+func (l *layered) GetString(key string) string {
+	return l.values[l.prefix+key]
+}
+
+func (l *layered) GetDuration(key string) time.Duration {
+	d, _ := time.ParseDuration(l.GetString(key))
+	return d
+}
+
+func (l *layered) GetInt(key string) int {
+	i, _ := strconv.Atoi(l.GetString(key))
+	return i
+}
+
+func (l *layered) GetBool(key string) bool {
+	b, _ := strconv.ParseBool(l.GetString(key))
+	return b
+}
+
+func (l *layered) IsSet(key string) bool {
+	_, ok := l.values[l.prefix+key]
+	return ok
+}
+
+func (l *layered) Sub(key string) Provider {
+	return &layered{
+		prefix: l.prefix + key + ".",
+		values: l.values,
+	}
+}
+
+// flatten turns nested sections into dot separated keys
+func flatten(prefix string, section map[string]interface{}, values map[string]string) {
+	for key, value := range section {
+		if sub, ok := value.(map[string]interface{}); ok {
+			flatten(prefix+key+".", sub, values)
+			continue
+		}
+		values[prefix+key] = format(value)
+	}
+}
+
+func format(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = format(item)
+		}
+		return strings.Join(items, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func validate(defaults map[string]interface{}, values map[string]string) error {
+	var invalid []string
+	for key, def := range defaults {
+		var err error
+		switch def.(type) {
+		case time.Duration:
+			_, err = time.ParseDuration(values[key])
+		case int:
+			_, err = strconv.Atoi(values[key])
+		case bool:
+			_, err = strconv.ParseBool(values[key])
+		}
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s: invalid value %q", key, values[key]))
+		}
+	}
+	if len(invalid) == 0 {
+		return nil
+	}
+	sort.Strings(invalid)
+	return errors.New("invalid config: " + strings.Join(invalid, "; "))
+}
@@ -0,0 +1,128 @@
+package config_test
+
+import (
+	"github.com/nadilas/todo/config"
+	. "github.com/nadilas/todo/workflows/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var defaults = map[string]interface{}{
+	"mail.sender":           "tool@domain.com",
+	"reminder.timeout":      time.Minute,
+	"reminder.max_attempts": 3,
+	"reminder.enabled":      true,
+}
+
+func TestNew(t *testing.T) {
+	var path string
+	var env map[string]string
+	var provider config.Provider
+
+	Scenario(t, "defaults are used without file and environment",
+		Given(aConfigFile(t, &path, "")),
+		When(loaded(&provider, &path, &env)),
+		Then(func(t *testing.T) {
+			assert.Equal(t, "tool@domain.com", provider.GetString("mail.sender"))
+			assert.Equal(t, time.Minute, provider.GetDuration("reminder.timeout"))
+			assert.Equal(t, 3, provider.GetInt("reminder.max_attempts"))
+			assert.True(t, provider.GetBool("reminder.enabled"))
+			assert.False(t, provider.IsSet("reminder.unknown"))
+		}),
+	)
+	Scenario(t, "file overrides defaults",
+		Given(aConfigFile(t, &path, "mail:\n  sender: todo@domain.com\nreminder:\n  timeout: 5m\n  max_attempts: 5\n")),
+		When(loaded(&provider, &path, &env)),
+		Then(func(t *testing.T) {
+			assert.Equal(t, "todo@domain.com", provider.GetString("mail.sender"))
+			assert.Equal(t, time.Minute*5, provider.GetDuration("reminder.timeout"))
+			assert.Equal(t, 5, provider.GetInt("reminder.max_attempts"))
+		}),
+	)
+	Scenario(t, "json files are read too",
+		Given(aConfigFile(t, &path, `{"mail": {"sender": "json@domain.com"}}`)),
+		When(loaded(&provider, &path, &env)),
+		Then(func(t *testing.T) {
+			assert.Equal(t, "json@domain.com", provider.GetString("mail.sender"))
+		}),
+	)
+	Scenario(t, "environment overrides file",
+		Given(aConfigFile(t, &path, "mail:\n  sender: todo@domain.com\n")),
+		And(anEnvironment(&env, "TODO_MAIL_SENDER", "env@domain.com", "TODO_REMINDER_ENABLED", "false")),
+		When(loaded(&provider, &path, &env)),
+		Then(func(t *testing.T) {
+			assert.Equal(t, "env@domain.com", provider.GetString("mail.sender"))
+			assert.False(t, provider.GetBool("reminder.enabled"))
+		}),
+	)
+	Scenario(t, "sub-sections use relative keys",
+		Given(aConfigFile(t, &path, "")),
+		When(loaded(&provider, &path, &env)),
+		Then(func(t *testing.T) {
+			reminder := provider.Sub("reminder")
+			assert.Equal(t, time.Minute, reminder.GetDuration("timeout"))
+			assert.True(t, reminder.IsSet("max_attempts"))
+			assert.False(t, reminder.IsSet("mail.sender"))
+		}),
+	)
+	Scenario(t, "invalid values fail the load",
+		Given(aConfigFile(t, &path, "reminder:\n  timeout: soon\n")),
+		And(anEnvironment(&env, "TODO_REMINDER_MAX_ATTEMPTS", "many")),
+		Then(func(t *testing.T) {
+			_, err := config.New(defaults, path, lookup(env))
+			assert.EqualError(t, err, `invalid config: reminder.max_attempts: invalid value "many"; reminder.timeout: invalid value "soon"`)
+		}),
+	)
+	Scenario(t, "missing file fails the load",
+		Then(func(t *testing.T) {
+			_, err := config.New(defaults, filepath.Join(t.TempDir(), "missing.yaml"), lookup(nil))
+			assert.Error(t, err)
+		}),
+	)
+}
+
+func TestLoad(t *testing.T) {
+	provider, err := config.Load("")
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour*2, provider.GetDuration("reminder.schedule_to_close_timeout"))
+}
+
+func aConfigFile(t *testing.T, path *string, content string) func(t *testing.T) {
+	return func(st *testing.T) {
+		*path = ""
+		if content == "" {
+			return
+		}
+		*path = filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(st, ioutil.WriteFile(*path, []byte(content), 0600))
+	}
+}
+
+func anEnvironment(env *map[string]string, pairs ...string) func(t *testing.T) {
+	return func(t *testing.T) {
+		*env = map[string]string{}
+		for i := 0; i < len(pairs); i += 2 {
+			(*env)[pairs[i]] = pairs[i+1]
+		}
+	}
+}
+
+func loaded(provider *config.Provider, path *string, env *map[string]string) func(t *testing.T) {
+	return func(t *testing.T) {
+		var err error
+		*provider, err = config.New(defaults, *path, lookup(*env))
+		require.NoError(t, err)
+		*env = nil
+	}
+}
+
+func lookup(env map[string]string) func(key string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
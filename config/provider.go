@@ -1,3 +1,15 @@
 package config
 
-type Provider interface{}
+import "time"
+
+// Provider gives typed access to the configuration. Keys are dot separated paths, e.g. "mail.sender".
+type Provider interface {
+	GetString(key string) string
+	GetDuration(key string) time.Duration
+	GetInt(key string) int
+	GetBool(key string) bool
+	// IsSet reports whether any layer holds a value for the key
+	IsSet(key string) bool
+	// Sub returns the section under key, whose keys are relative to it
+	Sub(key string) Provider
+}
@@ -3,11 +3,17 @@ module github.com/nadilas/todo
 go 1.16
 
 require (
+	github.com/go-asn1-ber/asn1-ber v1.5.1
+	github.com/go-ldap/ldap/v3 v3.3.0
 	github.com/golang/mock v1.6.0
 	github.com/google/go-cmp v0.5.6
+	github.com/google/uuid v1.2.0
 	github.com/kr/pretty v0.3.0
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/stretchr/testify v1.7.0
 	go.temporal.io/api v1.4.1-0.20210420220407-6f00f7f98373
 	go.temporal.io/sdk v1.8.0
+	google.golang.org/grpc v1.37.0
 	google.golang.org/protobuf v1.27.1
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b
 )
@@ -1,4 +1,18 @@
 package interfaces
 
+import (
+	"context"
+	"errors"
+	"github.com/nadilas/todo/todopb"
+)
+
+// ErrUserNotFound is returned when the directory holds no matching user or group
+var ErrUserNotFound = errors.New("user not found")
+
 type ActiveDirectoryService interface {
+	LookupUser(ctx context.Context, samAccountName string) (*todopb.ADUser, error)
+	LookupByEmail(ctx context.Context, emailAddress string) (*todopb.ADUser, error)
+	ListGroupMembers(ctx context.Context, group string) ([]*todopb.ADUser, error)
+	// LookupManager returns the manager of the user, ErrUserNotFound if the user has none
+	LookupManager(ctx context.Context, samAccountName string) (*todopb.ADUser, error)
 }
@@ -2,9 +2,13 @@ package interfaces
 
 import (
 	"context"
+	"errors"
 	"github.com/nadilas/todo/todopb"
 )
 
+// ErrDeliveryRejected is returned when the mail server permanently rejected a reminder, retrying it won't help
+var ErrDeliveryRejected = errors.New("delivery rejected")
+
 type EmailService interface {
 	SendTaskReminder(ctx context.Context, reminderModel *todopb.TaskReminderModel, sender string, addressee ...string) error
 }
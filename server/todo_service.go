@@ -0,0 +1,309 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/nadilas/todo/todopb"
+	"github.com/nadilas/todo/workflows/signalproxy"
+	"github.com/nadilas/todo/workflows/todo"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"strings"
+)
+
+// TodoService is the gRPC frontend of the Tasklist workflows. Mutations are delivered through a SignalProxy
+// execution, reads are served by querying the owner's Tasklist.
+type TodoService struct {
+	todopb.UnimplementedTodoServiceServer
+	client    client.Client
+	taskQueue string
+}
+
+func NewTodoService(temporalClient client.Client, taskQueue string) *TodoService {
+	return &TodoService{
+		client:    temporalClient,
+		taskQueue: taskQueue,
+	}
+}
+
+func (s *TodoService) AddTodo(ctx context.Context, request *todopb.AddTodoRequest) (*todopb.AddTodoResponse, error) {
+	if request.Item == nil {
+		return nil, status.Error(codes.InvalidArgument, "todo is missing")
+	}
+	resp := &todopb.AddTodoResponse{}
+	if err := s.proxySignalWithStart(ctx, request.Owner, todo.AddTaskSignal, request, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *TodoService) UpdateTodo(ctx context.Context, request *todopb.UpdateTodoRequest) (*todopb.UpdateTodoResponse, error) {
+	if request.Item == nil {
+		return nil, status.Error(codes.InvalidArgument, "todo is not defined")
+	}
+	resp := &todopb.UpdateTodoResponse{}
+	if err := s.proxySignal(ctx, request.Owner, todo.UpdateTaskSignal, request, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *TodoService) DeleteTodo(ctx context.Context, request *todopb.DeleteTodoRequest) (*todopb.DeleteTodoResponse, error) {
+	resp := &todopb.DeleteTodoResponse{}
+	if err := s.proxySignal(ctx, request.Owner, todo.DeleteTaskSignal, request, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *TodoService) ReassignTodo(ctx context.Context, request *todopb.ReassignTodoRequest) (*todopb.ReassignTodoResponse, error) {
+	if request.Assignee == "" {
+		return nil, status.Error(codes.InvalidArgument, "assignee is missing")
+	}
+	resp := &todopb.ReassignTodoResponse{}
+	if err := s.proxySignal(ctx, request.Owner, todo.ReassignTaskSignal, request, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *TodoService) AddComment(ctx context.Context, request *todopb.AddCommentRequest) (*todopb.AddCommentResponse, error) {
+	if strings.TrimSpace(request.Text) == "" {
+		return nil, status.Error(codes.InvalidArgument, "comment is missing")
+	}
+	resp := &todopb.AddCommentResponse{}
+	if err := s.proxySignal(ctx, request.Owner, todo.AddCommentSignal, request, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *TodoService) GetTodoHistory(ctx context.Context, request *todopb.GetTodoHistoryRequest) (*todopb.TodoHistory, error) {
+	if request.Owner == "" {
+		return nil, status.Error(codes.InvalidArgument, "owner is missing")
+	}
+	value, err := s.client.QueryWorkflow(ctx, todo.TasklistWorkflowId(request.Owner), "", todo.TaskHistoryQuery, request.Uuid)
+	if err != nil {
+		var notFound *serviceerror.NotFound
+		var queryFailed *serviceerror.QueryFailed
+		switch {
+		case errors.As(err, &notFound):
+			// no running Tasklist has no todo
+			return nil, status.Errorf(codes.NotFound, "task not found: %s", request.Uuid)
+		case errors.As(err, &queryFailed):
+			return nil, status.Error(errorCode(queryFailed.Message), queryFailed.Message)
+		}
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	var history *todopb.TodoHistory
+	if err := value.Get(&history); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return history, nil
+}
+
+func (s *TodoService) SnoozeTodo(ctx context.Context, request *todopb.SnoozeTodoRequest) (*todopb.SnoozeTodoResponse, error) {
+	if request.Duration == "" && request.Until == nil {
+		return nil, status.Error(codes.InvalidArgument, "duration or until is missing")
+	}
+	resp := &todopb.SnoozeTodoResponse{}
+	if err := s.proxySignal(ctx, request.Owner, todo.SnoozeTaskSignal, request, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *TodoService) SetDigest(ctx context.Context, request *todopb.SetDigestRequest) (*todopb.SetDigestResponse, error) {
+	resp := &todopb.SetDigestResponse{}
+	// the preference is kept by the Tasklist, which is started to hold it if none is running
+	if err := s.proxySignalWithStart(ctx, request.Owner, todo.SetDigestSignal, request, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *TodoService) ListPendingTodos(ctx context.Context, request *todopb.ListTodosRequest) (*todopb.ListTodosResponse, error) {
+	return s.queryTasks(ctx, request.Owner, todo.PendingTasksQuery)
+}
+
+func (s *TodoService) ListBlockedTodos(ctx context.Context, request *todopb.ListTodosRequest) (*todopb.ListTodosResponse, error) {
+	return s.queryTasks(ctx, request.Owner, todo.BlockedTasksQuery)
+}
+
+func (s *TodoService) ListAllTodos(ctx context.Context, request *todopb.ListTodosRequest) (*todopb.ListTodosResponse, error) {
+	return s.queryTasks(ctx, request.Owner, todo.AllTasksQuery)
+}
+
+func (s *TodoService) ListOverdueTodos(ctx context.Context, request *todopb.ListTodosRequest) (*todopb.ListTodosResponse, error) {
+	return s.queryTasks(ctx, request.Owner, todo.OverdueTasksQuery)
+}
+
+func (s *TodoService) FilterTodos(ctx context.Context, request *todopb.FilterTodosRequest) (*todopb.ListTodosResponse, error) {
+	filter := request.Filter
+	if filter == nil {
+		filter = &todopb.TodoFilter{}
+	}
+	if _, err := todo.ParseLabelSelector(filter.LabelSelector); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return s.queryTasks(ctx, request.Owner, todo.FilteredTasksQuery, filter)
+}
+
+func (s *TodoService) ListTodoTree(ctx context.Context, request *todopb.ListTodosRequest) (*todopb.ListTodosResponse, error) {
+	return s.queryTasks(ctx, request.Owner, todo.TaskTreeQuery)
+}
+
+// proxySignal delivers the request to the owner's Tasklist through a SignalProxy execution and unpacks the
+// returned data into resp
+func (s *TodoService) proxySignal(ctx context.Context, owner, signalName string, request proto.Message, resp proto.Message) error {
+	if owner == "" {
+		return status.Error(codes.InvalidArgument, "owner is missing")
+	}
+	data, err := anypb.New(request)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	run, err := s.client.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		TaskQueue: s.taskQueue,
+	}, signalproxy.SignalProxy, &signalproxy.Payload{
+		TargetId:   todo.TasklistWorkflowId(owner),
+		SignalName: signalName,
+		Data:       data,
+	})
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	var result *signalproxy.Result
+	if err := run.Get(ctx, &result); err != nil {
+		// the proxy only fails if the signal could not be delivered to the Tasklist
+		return status.Errorf(codes.NotFound, "tasklist of %s cannot be reached: %s", owner, err.Error())
+	}
+	return unpackResult(result, resp)
+}
+
+// proxySignalWithStart delivers the request to the owner's Tasklist with signal-with-start, so the Tasklist is started
+// if it isn't running. The SignalProxy execution only awaits the completion of the signal.
+func (s *TodoService) proxySignalWithStart(ctx context.Context, owner, signalName string, request proto.Message, resp proto.Message) error {
+	if owner == "" {
+		return status.Error(codes.InvalidArgument, "owner is missing")
+	}
+	data, err := anypb.New(request)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	targetId := todo.TasklistWorkflowId(owner)
+	run, err := s.client.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		ID:        "signalproxy-" + uuid.New().String(),
+		TaskQueue: s.taskQueue,
+	}, signalproxy.SignalProxy, &signalproxy.Payload{
+		TargetId:        targetId,
+		SignalName:      signalName,
+		Data:            data,
+		SignalDelivered: true,
+	})
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	_, err = s.client.SignalWithStartWorkflow(ctx, targetId, signalName, signalproxy.InputData{
+		CompletionTargetId: run.GetID(),
+		Data:               data,
+	}, client.StartWorkflowOptions{
+		ID:                    targetId,
+		TaskQueue:             s.taskQueue,
+		WorkflowIDReusePolicy: enums.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE,
+	}, todo.Tasklist, &todo.Tasks{Owner: owner})
+	if err != nil {
+		// nobody is going to complete the proxy
+		_ = s.client.CancelWorkflow(ctx, run.GetID(), run.GetRunID())
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	var result *signalproxy.Result
+	if err := run.Get(ctx, &result); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return unpackResult(result, resp)
+}
+
+func (s *TodoService) queryTasks(ctx context.Context, owner, queryType string, args ...interface{}) (*todopb.ListTodosResponse, error) {
+	if owner == "" {
+		return nil, status.Error(codes.InvalidArgument, "owner is missing")
+	}
+	value, err := s.client.QueryWorkflow(ctx, todo.TasklistWorkflowId(owner), "", queryType, args...)
+	if err != nil {
+		var notFound *serviceerror.NotFound
+		if errors.As(err, &notFound) {
+			// no running Tasklist means there is nothing to do
+			return &todopb.ListTodosResponse{}, nil
+		}
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	var items []*todopb.TodoItem
+	if err := value.Get(&items); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &todopb.ListTodosResponse{
+		Items: items,
+	}, nil
+}
+
+// unpackResult translates a signalproxy.Result into either resp or a gRPC status error
+func unpackResult(result *signalproxy.Result, resp proto.Message) error {
+	if result == nil {
+		return status.Error(codes.Internal, "signal completed without result")
+	}
+	if !result.Success {
+		return status.Error(errorCode(result.Error), result.Error)
+	}
+	if result.Data == nil {
+		return nil
+	}
+	if err := result.Data.UnmarshalTo(resp); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+// invalidPrefixes and invalidSuffixes match the messages of the requests the Tasklist rejected as invalid
+var (
+	invalidPrefixes = []string{"invalid ", "unknown assignee ", "a todo can't ", "subtasks are added ", "comments are added ", "todo has "}
+	invalidSuffixes = []string{" is missing", " is not defined"}
+)
+
+// errorCode maps the error messages reported by the Tasklist signal handlers to gRPC status codes. Messages not known
+// to reject the request, e.g. a failed directory lookup, are failures of the service.
+func errorCode(errMsg string) codes.Code {
+	switch {
+	case strings.HasPrefix(errMsg, "task not found"):
+		return codes.NotFound
+	case strings.HasPrefix(errMsg, "task exists already"):
+		return codes.AlreadyExists
+	case strings.HasPrefix(errMsg, "revision conflict"):
+		return codes.Aborted
+	case strings.HasPrefix(errMsg, "looking up assignee "):
+		return codes.Unavailable
+	}
+	for _, prefix := range invalidPrefixes {
+		if strings.HasPrefix(errMsg, prefix) {
+			return codes.InvalidArgument
+		}
+	}
+	for _, suffix := range invalidSuffixes {
+		if strings.HasSuffix(errMsg, suffix) {
+			return codes.InvalidArgument
+		}
+	}
+	return codes.Internal
+}
@@ -0,0 +1,621 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/nadilas/todo/server"
+	"github.com/nadilas/todo/todopb"
+	"github.com/nadilas/todo/workflows/signalproxy"
+	. "github.com/nadilas/todo/workflows/testutils"
+	"github.com/nadilas/todo/workflows/todo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/testsuite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"net"
+	"testing"
+	"time"
+)
+
+type TodoServiceTestSuite struct {
+	BDTestSuite
+}
+
+func TestTodoServiceTestSuite(t *testing.T) {
+	suite.Run(t, &TodoServiceTestSuite{})
+}
+
+func (s *TodoServiceTestSuite) setupMocks(mockCtrl *gomock.Controller) {
+	// no-op
+}
+
+func (s *TodoServiceTestSuite) Test_TodoService() {
+	var temporal *testTemporal
+	var conn todopb.TodoServiceClient
+	dummyTask := &todopb.TodoItem{
+		Uuid:        "t1",
+		Description: "some task",
+		CreatedBy:   "user1",
+		CreatedAt:   timestamppb.Now(),
+	}
+	dummyCompletedTask := &todopb.TodoItem{
+		Uuid:        "t2",
+		Description: "some task 2",
+		CreatedBy:   "user1",
+		CreatedAt:   timestamppb.Now(),
+		CompletedAt: timestamppb.Now(),
+		CompletedBy: "user1",
+	}
+
+	s.Scenario("adding a todo is listed as pending",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal, dummyCompletedTask)),
+		s.And(aServer(&temporal, &conn)),
+		s.When(todoAdded(&conn, "user1", dummyTask, codes.OK)),
+		s.Then(todosListed(&conn, "user1", false, dummyTask)),
+		s.And(todosListed(&conn, "user1", true, dummyCompletedTask, dummyTask)),
+	)
+	s.Scenario("adding a todo without owner is rejected",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal)),
+		s.And(aServer(&temporal, &conn)),
+		s.When(todoAdded(&conn, "", dummyTask, codes.InvalidArgument)),
+	)
+	s.Scenario("adding a todo with the uuid of another todo is rejected",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal, dummyTask)),
+		s.And(aServer(&temporal, &conn)),
+		s.When(todoAdded(&conn, "user1", dummyTask, codes.AlreadyExists)),
+	)
+	s.Scenario("adding to a tasklist which is not running starts it",
+		s.setupMocks,
+		s.Given(noTasklist(&temporal)),
+		s.And(aServer(&temporal, &conn)),
+		s.When(todoAdded(&conn, "user1", dummyTask, codes.OK)),
+		s.Then(todosListed(&conn, "user1", false, dummyTask)),
+	)
+	s.Scenario("adding to a tasklist which closed after its last todo starts it again",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal, dummyTask)),
+		s.And(aServer(&temporal, &conn)),
+		s.And(todoDeleted(&conn, "user1", "t1", codes.OK)),
+		s.When(todoAdded(&conn, "user1", dummyTask, codes.OK)),
+		s.Then(todosListed(&conn, "user1", false, dummyTask)),
+	)
+	s.Scenario("updating a tasklist which is not running fails",
+		s.setupMocks,
+		s.Given(noTasklist(&temporal)),
+		s.And(aServer(&temporal, &conn)),
+		s.When(todoUpdated(&conn, "user1", dummyTask, codes.NotFound)),
+	)
+	s.Scenario("listing a tasklist which is not running is empty",
+		s.setupMocks,
+		s.Given(noTasklist(&temporal)),
+		s.And(aServer(&temporal, &conn)),
+		s.Then(todosListed(&conn, "user1", true)),
+	)
+	s.Scenario("updating an unknown todo is not found",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal, dummyTask)),
+		s.And(aServer(&temporal, &conn)),
+		s.When(todoUpdated(&conn, "user1", dummyCompletedTask, codes.NotFound)),
+	)
+	s.Scenario("completing a todo removes it from pending",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal, dummyTask, dummyCompletedTask)),
+		s.And(aServer(&temporal, &conn)),
+		s.When(todoUpdated(&conn, "user1", &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			CompletedAt: timestamppb.Now(),
+			CompletedBy: "user1",
+		}, codes.OK)),
+		s.Then(todosListed(&conn, "user1", false)),
+	)
+	s.Scenario("updating a todo at a stale revision is a conflict",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal, dummyTask)),
+		s.And(aServer(&temporal, &conn)),
+		s.When(todoUpdatedAt(&conn, "user1", &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task changed",
+		}, 5, codes.Aborted)),
+	)
+	s.Scenario("deleting a todo removes it",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal, dummyTask, dummyCompletedTask)),
+		s.And(aServer(&temporal, &conn)),
+		s.When(todoDeleted(&conn, "user1", "t2", codes.OK)),
+		s.Then(todosListed(&conn, "user1", true, dummyTask)),
+	)
+	s.Scenario("listing overdue todos leaves out those due later",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal, dummyTask, &todopb.TodoItem{
+			Uuid:        "t3",
+			Description: "some overdue task",
+			CreatedBy:   "user1",
+			CreatedAt:   timestamppb.Now(),
+			DueAt:       timestamppb.New(time.Now().Add(-time.Hour * 24)),
+			DueSoonSent: true,
+			OverdueSent: true,
+		}, &todopb.TodoItem{
+			Uuid:        "t4",
+			Description: "some task due next year",
+			CreatedBy:   "user1",
+			CreatedAt:   timestamppb.Now(),
+			DueAt:       timestamppb.New(time.Now().Add(time.Hour * 24 * 365)),
+		})),
+		s.And(aServer(&temporal, &conn)),
+		s.Then(func(suite **BDTestSuite) {
+			st := *suite
+			resp, err := conn.ListOverdueTodos(context.Background(), &todopb.ListTodosRequest{
+				Owner: "user1",
+			})
+			st.Require().NoError(err)
+			if st.Len(resp.Items, 1) {
+				st.Equal("t3", resp.Items[0].Uuid)
+				st.Contains(resp.Items[0].Due, "overdue")
+			}
+		}),
+	)
+	s.Scenario("filtering todos by label",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal, dummyTask, &todopb.TodoItem{
+			Uuid:        "t3",
+			Description: "some labelled task",
+			CreatedBy:   "user1",
+			CreatedAt:   timestamppb.Now(),
+			Labels:      map[string]string{"team": "payments"},
+		})),
+		s.And(aServer(&temporal, &conn)),
+		s.Then(func(suite **BDTestSuite) {
+			st := *suite
+			resp, err := conn.FilterTodos(context.Background(), &todopb.FilterTodosRequest{
+				Owner:  "user1",
+				Filter: &todopb.TodoFilter{LabelSelector: "team=payments"},
+			})
+			st.Require().NoError(err)
+			if st.Len(resp.Items, 1) {
+				st.Equal("t3", resp.Items[0].Uuid)
+			}
+		}),
+	)
+	s.Scenario("listing blocked todos leaves out those whose blockers are completed",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal, dummyTask, dummyCompletedTask, &todopb.TodoItem{
+			Uuid:        "t3",
+			Description: "some blocked task",
+			CreatedBy:   "user1",
+			CreatedAt:   timestamppb.Now(),
+			BlockedBy:   []string{"t1"},
+		}, &todopb.TodoItem{
+			Uuid:        "t4",
+			Description: "some unblocked task",
+			CreatedBy:   "user1",
+			CreatedAt:   timestamppb.Now(),
+			BlockedBy:   []string{"t2"},
+		})),
+		s.And(aServer(&temporal, &conn)),
+		s.Then(func(suite **BDTestSuite) {
+			st := *suite
+			resp, err := conn.ListBlockedTodos(context.Background(), &todopb.ListTodosRequest{
+				Owner: "user1",
+			})
+			st.Require().NoError(err)
+			if st.Len(resp.Items, 1) {
+				st.Equal("t3", resp.Items[0].Uuid)
+			}
+		}),
+	)
+	s.Scenario("listing the todo tree nests subtasks",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal, dummyTask, &todopb.TodoItem{
+			Uuid:        "t3",
+			Description: "some subtask",
+			CreatedBy:   "user1",
+			CreatedAt:   timestamppb.Now(),
+			ParentUuid:  "t1",
+		})),
+		s.And(aServer(&temporal, &conn)),
+		s.Then(func(suite **BDTestSuite) {
+			st := *suite
+			resp, err := conn.ListTodoTree(context.Background(), &todopb.ListTodosRequest{
+				Owner: "user1",
+			})
+			st.Require().NoError(err)
+			if st.Len(resp.Items, 1) && st.Len(resp.Items[0].Subtasks, 1) {
+				st.Equal("t3", resp.Items[0].Subtasks[0].Uuid)
+			}
+		}),
+	)
+	s.Scenario("filtering todos with an invalid label selector is rejected",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal, dummyTask)),
+		s.And(aServer(&temporal, &conn)),
+		s.Then(func(suite **BDTestSuite) {
+			st := *suite
+			_, err := conn.FilterTodos(context.Background(), &todopb.FilterTodosRequest{
+				Owner:  "user1",
+				Filter: &todopb.TodoFilter{LabelSelector: "!"},
+			})
+			st.Equal(codes.InvalidArgument, status.Code(err), "unexpected status: %v", err)
+		}),
+	)
+	s.Scenario("setting a digest preference returns it",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal, dummyTask)),
+		s.And(aServer(&temporal, &conn)),
+		s.Then(func(suite **BDTestSuite) {
+			st := *suite
+			resp, err := conn.SetDigest(context.Background(), &todopb.SetDigestRequest{
+				Owner:  "user1",
+				Digest: &todopb.Digest{Window: "24h"},
+			})
+			st.Require().NoError(err)
+			st.Equal("24h", resp.Digest.GetWindow())
+		}),
+	)
+	s.Scenario("setting a digest preference without a running tasklist starts one holding it",
+		s.setupMocks,
+		s.Given(noTasklist(&temporal)),
+		s.And(aServer(&temporal, &conn)),
+		s.Then(func(suite **BDTestSuite) {
+			st := *suite
+			resp, err := conn.SetDigest(context.Background(), &todopb.SetDigestRequest{
+				Owner:  "user1",
+				Digest: &todopb.Digest{Window: "24h"},
+			})
+			st.Require().NoError(err)
+			st.Equal("24h", resp.Digest.GetWindow())
+			st.True(temporal.running, "the tasklist closed with the preference")
+		}),
+	)
+	s.Scenario("setting an invalid digest preference is rejected",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal, dummyTask)),
+		s.And(aServer(&temporal, &conn)),
+		s.Then(func(suite **BDTestSuite) {
+			_, err := conn.SetDigest(context.Background(), &todopb.SetDigestRequest{
+				Owner:  "user1",
+				Digest: &todopb.Digest{Window: "daily"},
+			})
+			(*suite).Equal(codes.InvalidArgument, status.Code(err), "unexpected status: %v", err)
+		}),
+	)
+	s.Scenario("snoozing without duration or until is rejected",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal, dummyTask)),
+		s.And(aServer(&temporal, &conn)),
+		s.When(todoSnoozed(&conn, "user1", "t1", "", codes.InvalidArgument)),
+	)
+	s.Scenario("snoozing a todo without reminder is rejected",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal, dummyTask)),
+		s.And(aServer(&temporal, &conn)),
+		s.When(todoSnoozed(&conn, "user1", "t1", "2h", codes.InvalidArgument)),
+	)
+	s.Scenario("snoozing an unknown todo is not found",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal, dummyTask)),
+		s.And(aServer(&temporal, &conn)),
+		s.When(todoSnoozed(&conn, "user1", "t2", "2h", codes.NotFound)),
+	)
+	s.Scenario("commenting a todo adds the comment to its history",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal, dummyTask)),
+		s.And(aServer(&temporal, &conn)),
+		s.When(todoCommented(&conn, "user1", "t1", "waiting for the invoice", codes.OK)),
+		s.Then(historyListed(&conn, "user1", "t1", codes.OK, "waiting for the invoice")),
+	)
+	s.Scenario("an empty comment is rejected",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal, dummyTask)),
+		s.And(aServer(&temporal, &conn)),
+		s.When(todoCommented(&conn, "user1", "t1", "", codes.InvalidArgument)),
+	)
+	s.Scenario("the history of a tasklist which is not running is not found",
+		s.setupMocks,
+		s.Given(noTasklist(&temporal)),
+		s.And(aServer(&temporal, &conn)),
+		s.Then(historyListed(&conn, "user1", "t1", codes.NotFound)),
+	)
+	s.Scenario("reassigning without assignee is rejected",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal, dummyTask)),
+		s.And(aServer(&temporal, &conn)),
+		s.When(todoReassigned(&conn, "user1", "t1", "", codes.InvalidArgument)),
+	)
+	s.Scenario("reassigning an unknown todo is not found",
+		s.setupMocks,
+		s.Given(aRunningTasklist(&temporal, dummyTask)),
+		s.And(aServer(&temporal, &conn)),
+		s.When(todoReassigned(&conn, "user1", "t2", "user2", codes.NotFound)),
+	)
+}
+
+func aRunningTasklist(temporal **testTemporal, items ...*todopb.TodoItem) func(suite **BDTestSuite) {
+	return func(suite **BDTestSuite) {
+		*temporal = &testTemporal{
+			running: true,
+			items:   items,
+		}
+	}
+}
+
+func noTasklist(temporal **testTemporal) func(suite **BDTestSuite) {
+	return func(suite **BDTestSuite) {
+		*temporal = &testTemporal{}
+	}
+}
+
+func aServer(temporal **testTemporal, conn *todopb.TodoServiceClient) func(suite **BDTestSuite) {
+	return func(suite **BDTestSuite) {
+		st := *suite
+		listener := bufconn.Listen(1024 * 1024)
+		srv := grpc.NewServer()
+		todopb.RegisterTodoServiceServer(srv, server.NewTodoService(*temporal, "todo"))
+		go func() {
+			_ = srv.Serve(listener)
+		}()
+		st.T().Cleanup(srv.Stop)
+
+		cc, err := grpc.Dial("bufnet", grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.Dial()
+		}), grpc.WithInsecure())
+		st.Require().NoError(err)
+		st.T().Cleanup(func() {
+			_ = cc.Close()
+		})
+		*conn = todopb.NewTodoServiceClient(cc)
+	}
+}
+
+func todoAdded(conn *todopb.TodoServiceClient, owner string, item *todopb.TodoItem, expectedCode codes.Code) func(suite **BDTestSuite) {
+	return func(suite **BDTestSuite) {
+		st := *suite
+		resp, err := (*conn).AddTodo(context.Background(), &todopb.AddTodoRequest{
+			Item:  item,
+			Owner: owner,
+		})
+		st.Equal(expectedCode, status.Code(err), "unexpected status: %v", err)
+		if expectedCode == codes.OK {
+			st.Equal(item.Uuid, resp.Item.Uuid)
+		}
+	}
+}
+
+func todoUpdated(conn *todopb.TodoServiceClient, owner string, item *todopb.TodoItem, expectedCode codes.Code) func(suite **BDTestSuite) {
+	return func(suite **BDTestSuite) {
+		st := *suite
+		resp, err := (*conn).UpdateTodo(context.Background(), &todopb.UpdateTodoRequest{
+			Item:  item,
+			Owner: owner,
+		})
+		st.Equal(expectedCode, status.Code(err), "unexpected status: %v", err)
+		if expectedCode == codes.OK {
+			st.Equal(item.Description, resp.Item.Description)
+		}
+	}
+}
+
+func todoUpdatedAt(conn *todopb.TodoServiceClient, owner string, item *todopb.TodoItem, expectedRevision int64, expectedCode codes.Code) func(suite **BDTestSuite) {
+	return func(suite **BDTestSuite) {
+		st := *suite
+		_, err := (*conn).UpdateTodo(context.Background(), &todopb.UpdateTodoRequest{
+			Item:             item,
+			Owner:            owner,
+			ExpectedRevision: expectedRevision,
+		})
+		st.Equal(expectedCode, status.Code(err), "unexpected status: %v", err)
+	}
+}
+
+func todoDeleted(conn *todopb.TodoServiceClient, owner string, uuid string, expectedCode codes.Code) func(suite **BDTestSuite) {
+	return func(suite **BDTestSuite) {
+		st := *suite
+		_, err := (*conn).DeleteTodo(context.Background(), &todopb.DeleteTodoRequest{
+			Uuid:  uuid,
+			Owner: owner,
+		})
+		st.Equal(expectedCode, status.Code(err), "unexpected status: %v", err)
+	}
+}
+
+func todoSnoozed(conn *todopb.TodoServiceClient, owner string, uuid string, duration string, expectedCode codes.Code) func(suite **BDTestSuite) {
+	return func(suite **BDTestSuite) {
+		st := *suite
+		_, err := (*conn).SnoozeTodo(context.Background(), &todopb.SnoozeTodoRequest{
+			Uuid:     uuid,
+			Owner:    owner,
+			Duration: duration,
+		})
+		st.Equal(expectedCode, status.Code(err), "unexpected status: %v", err)
+	}
+}
+
+func todoReassigned(conn *todopb.TodoServiceClient, owner string, uuid string, assignee string, expectedCode codes.Code) func(suite **BDTestSuite) {
+	return func(suite **BDTestSuite) {
+		st := *suite
+		_, err := (*conn).ReassignTodo(context.Background(), &todopb.ReassignTodoRequest{
+			Uuid:     uuid,
+			Owner:    owner,
+			Assignee: assignee,
+		})
+		st.Equal(expectedCode, status.Code(err), "unexpected status: %v", err)
+	}
+}
+
+func todoCommented(conn *todopb.TodoServiceClient, owner string, uuid string, text string, expectedCode codes.Code) func(suite **BDTestSuite) {
+	return func(suite **BDTestSuite) {
+		st := *suite
+		_, err := (*conn).AddComment(context.Background(), &todopb.AddCommentRequest{
+			Uuid:  uuid,
+			Owner: owner,
+			Text:  text,
+		})
+		st.Equal(expectedCode, status.Code(err), "unexpected status: %v", err)
+	}
+}
+
+// historyListed expects the history of the todo to have the comments with the texts
+func historyListed(conn *todopb.TodoServiceClient, owner string, uuid string, expectedCode codes.Code, expectedTexts ...string) func(suite **BDTestSuite) {
+	return func(suite **BDTestSuite) {
+		st := *suite
+		history, err := (*conn).GetTodoHistory(context.Background(), &todopb.GetTodoHistoryRequest{
+			Uuid:  uuid,
+			Owner: owner,
+		})
+		if !st.Equal(expectedCode, status.Code(err), "unexpected status: %v", err) || err != nil {
+			return
+		}
+		var texts []string
+		for _, comment := range history.Comments {
+			texts = append(texts, comment.Text)
+		}
+		st.Equal(expectedTexts, texts)
+	}
+}
+
+func todosListed(conn *todopb.TodoServiceClient, owner string, all bool, expectedTasks ...*todopb.TodoItem) func(suite **BDTestSuite) {
+	return func(suite **BDTestSuite) {
+		st := *suite
+		list := (*conn).ListPendingTodos
+		if all {
+			list = (*conn).ListAllTodos
+		}
+		resp, err := list(context.Background(), &todopb.ListTodosRequest{
+			Owner: owner,
+		})
+		st.Require().NoError(err)
+		uuids := make([]string, 0, len(resp.Items))
+		for _, item := range resp.Items {
+			uuids = append(uuids, item.Uuid)
+		}
+		expectedUuids := make([]string, 0, len(expectedTasks))
+		for _, item := range expectedTasks {
+			expectedUuids = append(expectedUuids, item.Uuid)
+		}
+		st.Equal(expectedUuids, uuids)
+	}
+}
+
+// testTemporal is a client.Client executing the SignalProxy and the owner's Tasklist on the Temporal test environment
+type testTemporal struct {
+	client.Client
+	running     bool
+	items       []*todopb.TodoItem
+	completions map[string]*signalproxy.Result
+}
+
+func (c *testTemporal) ExecuteWorkflow(ctx context.Context, options client.StartWorkflowOptions, workflow interface{}, args ...interface{}) (client.WorkflowRun, error) {
+	// the execution is deferred to Get, as a signal-with-start may complete it in the meantime
+	return &testRun{
+		temporal: c,
+		id:       options.ID,
+		workflow: workflow,
+		args:     args,
+	}, nil
+}
+
+func (c *testTemporal) SignalWithStartWorkflow(ctx context.Context, workflowID string, signalName string, signalArg interface{},
+	options client.StartWorkflowOptions, workflow interface{}, workflowArgs ...interface{}) (client.WorkflowRun, error) {
+	if !c.running {
+		c.running = true
+		c.items = workflowArgs[0].(*todo.Tasks).Items
+	}
+	data := signalArg.(signalproxy.InputData)
+	if c.completions == nil {
+		c.completions = map[string]*signalproxy.Result{}
+	}
+	c.completions[data.CompletionTargetId] = c.deliver(signalName, data)
+	return &testRun{id: workflowID}, nil
+}
+
+func (c *testTemporal) QueryWorkflow(ctx context.Context, workflowID string, runID string, queryType string, args ...interface{}) (converter.EncodedValue, error) {
+	if !c.running {
+		return nil, serviceerror.NewNotFound("workflow not found")
+	}
+	env := c.runTasklist(nil)
+	return env.QueryWorkflow(queryType, args...)
+}
+
+// deliver signals a Tasklist holding the current items and records its state afterwards
+func (c *testTemporal) deliver(signalName string, data signalproxy.InputData) *signalproxy.Result {
+	var result *signalproxy.Result
+	// every test environment runs as default-test-workflow-id, which would make the Tasklist signal itself
+	data.CompletionTargetId = "signalproxy"
+	env := c.runTasklist(func(env *testsuite.TestWorkflowEnvironment) {
+		env.OnSignalExternalWorkflow(mock.Anything, data.CompletionTargetId, mock.Anything, signalproxy.CompletedSignal, mock.Anything).Return(
+			func(namespace, workflowID, runID, signalName string, arg interface{}) error {
+				result = arg.(*signalproxy.Result)
+				return nil
+			})
+		env.RegisterDelayedCallback(func() {
+			env.SignalWorkflow(signalName, data)
+		}, time.Minute)
+	})
+	value, err := env.QueryWorkflow(todo.AllTasksQuery)
+	if err == nil {
+		var items []*todopb.TodoItem
+		_ = value.Get(&items)
+		c.items = items
+	}
+	// the Tasklist closes without error once nothing is pending, otherwise it continues as new
+	c.running = env.GetWorkflowError() != nil
+	return result
+}
+
+func (c *testTemporal) runTasklist(setup func(env *testsuite.TestWorkflowEnvironment)) *testsuite.TestWorkflowEnvironment {
+	env := (&testsuite.WorkflowTestSuite{}).NewTestWorkflowEnvironment()
+	if setup != nil {
+		setup(env)
+	}
+	env.ExecuteWorkflow(todo.Tasklist, &todo.Tasks{
+		Items: c.items,
+	})
+	return env
+}
+
+type testRun struct {
+	client.WorkflowRun
+	temporal *testTemporal
+	id       string
+	workflow interface{}
+	args     []interface{}
+}
+
+func (r *testRun) GetID() string {
+	return r.id
+}
+
+func (r *testRun) Get(ctx context.Context, valuePtr interface{}) error {
+	c := r.temporal
+	env := (&testsuite.WorkflowTestSuite{}).NewTestWorkflowEnvironment()
+	env.OnSignalExternalWorkflow(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(namespace, workflowID, runID, signalName string, arg interface{}) error {
+			if !c.running {
+				return errors.New("unknown external workflow execution")
+			}
+			data := arg.(signalproxy.InputData)
+			env.SignalWorkflowByID(data.CompletionTargetId, signalproxy.CompletedSignal, c.deliver(signalName, data))
+			return nil
+		})
+	if result, ok := c.completions[r.id]; ok {
+		env.RegisterDelayedCallback(func() {
+			env.SignalWorkflow(signalproxy.CompletedSignal, result)
+		}, time.Minute)
+	}
+	env.ExecuteWorkflow(r.workflow, r.args...)
+	if err := env.GetWorkflowError(); err != nil {
+		return err
+	}
+	return env.GetWorkflowResult(valuePtr)
+}
@@ -0,0 +1,191 @@
+package active_directory_test
+
+import (
+	"github.com/go-asn1-ber/asn1-ber"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	appBindRequest      = 0
+	appBindResponse     = 1
+	appUnbindRequest    = 2
+	appSearchRequest    = 3
+	appSearchResultItem = 4
+	appSearchResultDone = 5
+
+	resultSuccess            = 0
+	resultInvalidCredentials = 49
+	resultUnwillingToPerform = 53
+)
+
+// ldapStandIn is an in-process LDAP server answering simple binds and searches over a fixed set of entries. It
+// understands the and, or, not, equality and presence filters.
+type ldapStandIn struct {
+	listener net.Listener
+	bindDN   string
+	password string
+	entries  []ldapEntry
+
+	mu       sync.Mutex
+	searches []string
+}
+
+type ldapEntry struct {
+	dn         string
+	attributes map[string][]string
+}
+
+func startLdapStandIn(t *testing.T, bindDN, password string, entries ...ldapEntry) *ldapStandIn {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &ldapStandIn{
+		listener: listener,
+		bindDN:   bindDN,
+		password: password,
+		entries:  entries,
+	}
+	go s.serve()
+	t.Cleanup(func() {
+		listener.Close()
+	})
+	return s
+}
+
+func (s *ldapStandIn) url() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+func (s *ldapStandIn) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *ldapStandIn) handle(conn net.Conn) {
+	defer conn.Close()
+	bound := false
+	for {
+		request, err := ber.ReadPacket(conn)
+		if err != nil || len(request.Children) < 2 {
+			return
+		}
+		messageID := request.Children[0].Value.(int64)
+		op := request.Children[1]
+
+		switch op.Tag {
+		case appBindRequest:
+			dn := op.Children[1].Value.(string)
+			password := op.Children[2].Data.String()
+			code := int64(resultInvalidCredentials)
+			if dn == s.bindDN && password == s.password {
+				code = resultSuccess
+				bound = true
+			}
+			s.respond(conn, messageID, result(appBindResponse, code))
+		case appSearchRequest:
+			if !bound {
+				s.respond(conn, messageID, result(appSearchResultDone, resultUnwillingToPerform))
+				continue
+			}
+			s.mu.Lock()
+			s.searches = append(s.searches, op.Children[0].Value.(string))
+			s.mu.Unlock()
+			for _, entry := range s.entries {
+				if matches(op.Children[6], entry) {
+					s.respond(conn, messageID, entry.packet())
+				}
+			}
+			s.respond(conn, messageID, result(appSearchResultDone, resultSuccess))
+		case appUnbindRequest:
+			return
+		default:
+			return
+		}
+	}
+}
+
+func (s *ldapStandIn) respond(conn net.Conn, messageID int64, op *ber.Packet) {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "MessageID"))
+	packet.AppendChild(op)
+	conn.Write(packet.Bytes())
+}
+
+func result(tag ber.Tag, code int64) *ber.Packet {
+	packet := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, "resultCode"))
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "matchedDN"))
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "diagnosticMessage"))
+	return packet
+}
+
+func (e ldapEntry) packet() *ber.Packet {
+	packet := ber.Encode(ber.ClassApplication, ber.TypeConstructed, appSearchResultItem, nil, "Search Result Entry")
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, "objectName"))
+	attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "attributes")
+	for name, values := range e.attributes {
+		attribute := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "attribute")
+		attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "type"))
+		vals := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "vals")
+		for _, value := range values {
+			vals.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "value"))
+		}
+		attribute.AppendChild(vals)
+		attributes.AppendChild(attribute)
+	}
+	packet.AppendChild(attributes)
+	return packet
+}
+
+func matches(filter *ber.Packet, entry ldapEntry) bool {
+	switch filter.Tag {
+	case 0: // and
+		for _, child := range filter.Children {
+			if !matches(child, entry) {
+				return false
+			}
+		}
+		return true
+	case 1: // or
+		for _, child := range filter.Children {
+			if matches(child, entry) {
+				return true
+			}
+		}
+		return false
+	case 2: // not
+		return !matches(filter.Children[0], entry)
+	case 3: // equality
+		name := filter.Children[0].Value.(string)
+		value := filter.Children[1].Value.(string)
+		for _, v := range entry.values(name) {
+			if strings.EqualFold(v, value) {
+				return true
+			}
+		}
+		return false
+	case 7: // present
+		return len(entry.values(filter.Data.String())) > 0
+	default:
+		return false
+	}
+}
+
+// values returns the values of the attribute, whose names are case insensitive
+func (e ldapEntry) values(name string) []string {
+	for attribute, values := range e.attributes {
+		if strings.EqualFold(attribute, name) {
+			return values
+		}
+	}
+	return nil
+}
@@ -1,4 +1,194 @@
 package active_directory
 
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/nadilas/todo/config"
+	interfaces "github.com/nadilas/todo/if"
+	"github.com/nadilas/todo/todopb"
+	"strings"
+	"time"
+)
+
+var userAttributes = []string{"displayName", "mail", "sAMAccountName", "preferredLanguage"}
+
+// Service looks users up in an Active Directory over LDAP. It reads the "active_directory" section of the config on
+// every call and binds a new connection per lookup.
 type Service struct {
+	configProvider config.Provider
+}
+
+func NewService(configProvider config.Provider) *Service {
+	return &Service{configProvider: configProvider}
+}
+
+func (s *Service) LookupUser(ctx context.Context, samAccountName string) (*todopb.ADUser, error) {
+	return s.findUser(ctx, fmt.Sprintf("(&(objectClass=user)(sAMAccountName=%s))", ldap.EscapeFilter(samAccountName)))
+}
+
+func (s *Service) LookupByEmail(ctx context.Context, emailAddress string) (*todopb.ADUser, error) {
+	return s.findUser(ctx, fmt.Sprintf("(&(objectClass=user)(mail=%s))", ldap.EscapeFilter(emailAddress)))
+}
+
+// ListGroupMembers returns the users which are direct members of the group with the given common name
+func (s *Service) ListGroupMembers(ctx context.Context, group string) ([]*todopb.ADUser, error) {
+	conn, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	groups, err := s.search(conn, fmt.Sprintf("(&(objectClass=group)(cn=%s))", ldap.EscapeFilter(group)), []string{"cn"})
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("group %s: %w", group, interfaces.ErrUserNotFound)
+	}
+
+	entries, err := s.search(conn, fmt.Sprintf("(&(objectClass=user)(memberOf=%s))", ldap.EscapeFilter(groups[0].DN)), userAttributes)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]*todopb.ADUser, len(entries))
+	for i, entry := range entries {
+		users[i] = s.toUser(entry)
+	}
+	return users, nil
+}
+
+// LookupManager follows the manager attribute of the user
+func (s *Service) LookupManager(ctx context.Context, samAccountName string) (*todopb.ADUser, error) {
+	conn, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	filter := fmt.Sprintf("(&(objectClass=user)(sAMAccountName=%s))", ldap.EscapeFilter(samAccountName))
+	entries, err := s.search(conn, filter, []string{"manager"})
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%s: %w", filter, interfaces.ErrUserNotFound)
+	}
+	managerDN := entries[0].GetAttributeValue("manager")
+	if managerDN == "" {
+		return nil, fmt.Errorf("manager of %s: %w", samAccountName, interfaces.ErrUserNotFound)
+	}
+
+	entries, err = s.search(conn, fmt.Sprintf("(&(objectClass=user)(distinguishedName=%s))", ldap.EscapeFilter(managerDN)), userAttributes)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("manager %s: %w", managerDN, interfaces.ErrUserNotFound)
+	}
+	return s.toUser(entries[0]), nil
+}
+
+func (s *Service) findUser(ctx context.Context, filter string) (*todopb.ADUser, error) {
+	conn, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	entries, err := s.search(conn, filter, userAttributes)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%s: %w", filter, interfaces.ErrUserNotFound)
+	}
+	return s.toUser(entries[0]), nil
+}
+
+// connect dials the directory and binds with the configured credentials
+func (s *Service) connect(ctx context.Context) (*ldap.Conn, error) {
+	cfg := s.configProvider.Sub("active_directory")
+	timeout := cfg.GetDuration("timeout")
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < timeout {
+		timeout = time.Until(deadline)
+	}
+
+	conn, err := ldap.DialURL(cfg.GetString("url"), ldap.DialWithTLSConfig(&tls.Config{
+		InsecureSkipVerify: cfg.GetBool("insecure_skip_verify"),
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("dialing directory: %w", err)
+	}
+	conn.SetTimeout(timeout)
+
+	if cfg.GetBool("start_tls") {
+		host := cfg.GetString("url")
+		host = host[strings.Index(host, "://")+3:]
+		if i := strings.LastIndex(host, ":"); i >= 0 {
+			host = host[:i]
+		}
+		err = conn.StartTLS(&tls.Config{
+			ServerName:         host,
+			InsecureSkipVerify: cfg.GetBool("insecure_skip_verify"),
+		})
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("starting tls: %w", err)
+		}
+	}
+
+	if err := conn.Bind(cfg.GetString("bind_dn"), cfg.GetString("bind_password")); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("binding to directory: %w", err)
+	}
+	return conn, nil
+}
+
+func (s *Service) search(conn *ldap.Conn, filter string, attributes []string) ([]*ldap.Entry, error) {
+	result, err := conn.Search(ldap.NewSearchRequest(
+		s.configProvider.GetString("active_directory.base_dn"),
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		filter,
+		attributes,
+		nil,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("searching directory: %w", err)
+	}
+	return result.Entries, nil
+}
+
+func (s *Service) toUser(entry *ldap.Entry) *todopb.ADUser {
+	return &todopb.ADUser{
+		DisplayName:    entry.GetAttributeValue("displayName"),
+		Domain:         s.domain(),
+		EmailAddress:   entry.GetAttributeValue("mail"),
+		SamAccountName: entry.GetAttributeValue("sAMAccountName"),
+		Locale:         entry.GetAttributeValue("preferredLanguage"),
+	}
+}
+
+// domain returns the configured domain or the first component of the base DN, e.g. CORP for DC=corp,DC=example
+func (s *Service) domain() string {
+	if domain := s.configProvider.GetString("active_directory.domain"); domain != "" {
+		return domain
+	}
+	dn, err := ldap.ParseDN(s.configProvider.GetString("active_directory.base_dn"))
+	if err != nil {
+		return ""
+	}
+	for _, rdn := range dn.RDNs {
+		for _, attr := range rdn.Attributes {
+			if strings.EqualFold(attr.Type, "dc") {
+				return strings.ToUpper(attr.Value)
+			}
+		}
+	}
+	return ""
 }
@@ -0,0 +1,153 @@
+package active_directory_test
+
+import (
+	"context"
+	"errors"
+	"github.com/nadilas/todo/config"
+	interfaces "github.com/nadilas/todo/if"
+	active_directory "github.com/nadilas/todo/services/active-directory"
+	"github.com/nadilas/todo/todopb"
+	. "github.com/nadilas/todo/workflows/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+const (
+	bindDN   = "CN=todo,OU=Service,DC=corp,DC=example,DC=com"
+	password = "secret"
+	baseDN   = "DC=corp,DC=example,DC=com"
+	groupDN  = "CN=team,OU=Groups,DC=corp,DC=example,DC=com"
+)
+
+var (
+	jane = ldapEntry{
+		dn: "CN=Jane Doe,OU=Users,DC=corp,DC=example,DC=com",
+		attributes: map[string][]string{
+			"objectClass":       {"top", "person", "user"},
+			"displayName":       {"Jane Doe"},
+			"mail":              {"jane.doe@example.com"},
+			"sAMAccountName":    {"jdoe"},
+			"distinguishedName": {"CN=Jane Doe,OU=Users,DC=corp,DC=example,DC=com"},
+			"memberOf":          {groupDN},
+			"preferredLanguage": {"fr-FR"},
+		},
+	}
+	john = ldapEntry{
+		dn: "CN=John Roe,OU=Users,DC=corp,DC=example,DC=com",
+		attributes: map[string][]string{
+			"objectClass":    {"top", "person", "user"},
+			"displayName":    {"John Roe"},
+			"mail":           {"john.roe@example.com"},
+			"sAMAccountName": {"jroe"},
+			"manager":        {"CN=Jane Doe,OU=Users,DC=corp,DC=example,DC=com"},
+		},
+	}
+	team = ldapEntry{
+		dn: groupDN,
+		attributes: map[string][]string{
+			"objectClass": {"top", "group"},
+			"cn":          {"team"},
+		},
+	}
+	janeUser = &todopb.ADUser{
+		DisplayName:    "Jane Doe",
+		Domain:         "CORP",
+		EmailAddress:   "jane.doe@example.com",
+		SamAccountName: "jdoe",
+		Locale:         "fr-FR",
+	}
+)
+
+func TestService(t *testing.T) {
+	var server *ldapStandIn
+	var service *active_directory.Service
+
+	Scenario(t, "user is looked up by sAMAccountName",
+		Given(aDirectory(t, &server, &service, password)),
+		Then(func(t *testing.T) {
+			user, err := service.LookupUser(context.Background(), "jdoe")
+			require.NoError(t, err)
+			assert.Equal(t, janeUser, user)
+			assert.Equal(t, []string{baseDN}, server.searches)
+		}),
+	)
+	Scenario(t, "user is looked up by email",
+		Given(aDirectory(t, &server, &service, password)),
+		Then(func(t *testing.T) {
+			user, err := service.LookupByEmail(context.Background(), "jane.doe@example.com")
+			require.NoError(t, err)
+			assert.Equal(t, janeUser, user)
+		}),
+	)
+	Scenario(t, "unknown user is not found",
+		Given(aDirectory(t, &server, &service, password)),
+		Then(func(t *testing.T) {
+			_, err := service.LookupUser(context.Background(), "nobody")
+			assert.True(t, errors.Is(err, interfaces.ErrUserNotFound), "unexpected error: %v", err)
+		}),
+	)
+	Scenario(t, "filter values are escaped",
+		Given(aDirectory(t, &server, &service, password)),
+		Then(func(t *testing.T) {
+			_, err := service.LookupUser(context.Background(), "*")
+			assert.True(t, errors.Is(err, interfaces.ErrUserNotFound), "unexpected error: %v", err)
+		}),
+	)
+	Scenario(t, "manager is looked up by the manager attribute",
+		Given(aDirectory(t, &server, &service, password)),
+		Then(func(t *testing.T) {
+			manager, err := service.LookupManager(context.Background(), "jroe")
+			require.NoError(t, err)
+			assert.Equal(t, janeUser, manager)
+		}),
+	)
+	Scenario(t, "user without manager has none",
+		Given(aDirectory(t, &server, &service, password)),
+		Then(func(t *testing.T) {
+			_, err := service.LookupManager(context.Background(), "jdoe")
+			assert.True(t, errors.Is(err, interfaces.ErrUserNotFound), "unexpected error: %v", err)
+		}),
+	)
+	Scenario(t, "group members are listed",
+		Given(aDirectory(t, &server, &service, password)),
+		Then(func(t *testing.T) {
+			users, err := service.ListGroupMembers(context.Background(), "team")
+			require.NoError(t, err)
+			assert.Equal(t, []*todopb.ADUser{janeUser}, users)
+		}),
+	)
+	Scenario(t, "unknown group is not found",
+		Given(aDirectory(t, &server, &service, password)),
+		Then(func(t *testing.T) {
+			_, err := service.ListGroupMembers(context.Background(), "nobody")
+			assert.True(t, errors.Is(err, interfaces.ErrUserNotFound), "unexpected error: %v", err)
+		}),
+	)
+	Scenario(t, "invalid credentials fail the bind",
+		Given(aDirectory(t, &server, &service, "wrong")),
+		Then(func(t *testing.T) {
+			_, err := service.LookupUser(context.Background(), "jdoe")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "binding to directory")
+		}),
+	)
+}
+
+func aDirectory(t *testing.T, server **ldapStandIn, service **active_directory.Service, bindPassword string) func(t *testing.T) {
+	return func(st *testing.T) {
+		*server = startLdapStandIn(t, bindDN, password, jane, john, team)
+		env := map[string]string{
+			"TODO_ACTIVE_DIRECTORY_URL":           (*server).url(),
+			"TODO_ACTIVE_DIRECTORY_BIND_DN":       bindDN,
+			"TODO_ACTIVE_DIRECTORY_BIND_PASSWORD": bindPassword,
+			"TODO_ACTIVE_DIRECTORY_BASE_DN":       baseDN,
+		}
+		provider, err := config.New(config.Defaults, "", func(key string) (string, bool) {
+			value, ok := env[key]
+			return value, ok
+		})
+		require.NoError(st, err)
+		*service = active_directory.NewService(provider)
+	}
+}
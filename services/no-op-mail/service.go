@@ -3,11 +3,38 @@ package no_op_mail
 import (
 	"context"
 	"github.com/nadilas/todo/todopb"
+	"log"
+	"sync"
 )
 
+// Service logs and records the reminders instead of sending them
 type Service struct {
+	mu   sync.Mutex
+	sent []SentReminder
+}
+
+// SentReminder is a reminder recorded by the Service
+type SentReminder struct {
+	Model     *todopb.TaskReminderModel
+	Sender    string
+	Addressee []string
 }
 
 func (s *Service) SendTaskReminder(ctx context.Context, reminderModel *todopb.TaskReminderModel, sender string, addressee ...string) error {
-	panic("implement me")
+	log.Println("Not sending reminder", "subject", reminderModel.GetSubject(), "sender", sender, "addressee", addressee)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, SentReminder{
+		Model:     reminderModel,
+		Sender:    sender,
+		Addressee: addressee,
+	})
+	return nil
+}
+
+// Sent returns the reminders recorded so far
+func (s *Service) Sent() []SentReminder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SentReminder(nil), s.sent...)
 }
@@ -0,0 +1,23 @@
+package no_op_mail_test
+
+import (
+	"context"
+	no_op_mail "github.com/nadilas/todo/services/no-op-mail"
+	"github.com/nadilas/todo/todopb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestService(t *testing.T) {
+	service := &no_op_mail.Service{}
+	model := &todopb.TaskReminderModel{Subject: "Reminder from ToDo: buy milk"}
+
+	require.NoError(t, service.SendTaskReminder(context.Background(), model, "tool@domain.com", "jane.doe@example.com"))
+
+	assert.Equal(t, []no_op_mail.SentReminder{{
+		Model:     model,
+		Sender:    "tool@domain.com",
+		Addressee: []string{"jane.doe@example.com"},
+	}}, service.Sent())
+}
@@ -5,6 +5,7 @@ import (
 	interfaces "github.com/nadilas/todo/if"
 	active_directory "github.com/nadilas/todo/services/active-directory"
 	no_op_mail "github.com/nadilas/todo/services/no-op-mail"
+	smtp_mail "github.com/nadilas/todo/services/smtp-mail"
 	"sync"
 )
 
@@ -18,11 +19,15 @@ var (
 )
 
 func (k *kernel) InjectActiveDirectoryService() interfaces.ActiveDirectoryService {
-	return &active_directory.Service{}
+	return active_directory.NewService(k.configProvider)
 }
 
+// InjectEmailService returns the mailer selected by mail.service: smtp or no-op
 func (k *kernel) InjectEmailService() interfaces.EmailService {
-	return &no_op_mail.Service{}
+	if k.configProvider.GetString("mail.service") == "no-op" {
+		return &no_op_mail.Service{}
+	}
+	return smtp_mail.NewService(k.configProvider)
 }
 
 func ServiceContainer(configProvider config.Provider) *kernel {
@@ -0,0 +1,93 @@
+package smtp_mail
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/nadilas/todo/todopb"
+	"html"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// parseAddresses formats the addresses for a header
+func parseAddresses(addresses []string) ([]string, error) {
+	formatted := make([]string, len(addresses))
+	for i, a := range addresses {
+		address, err := mail.ParseAddress(a)
+		if err != nil {
+			return nil, err
+		}
+		formatted[i] = address.String()
+	}
+	return formatted, nil
+}
+
+// buildMessage renders the reminder as a multipart/alternative message with a plain text and an html part
+func buildMessage(model *todopb.TaskReminderModel, sender string, addressee []string, now time.Time) ([]byte, error) {
+	from, err := mail.ParseAddress(sender)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender: %w", err)
+	}
+	to, err := parseAddresses(addressee)
+	if err != nil {
+		return nil, fmt.Errorf("invalid addressee: %w", err)
+	}
+	cc, err := parseAddresses(model.Cc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cc: %w", err)
+	}
+
+	htmlContent := model.HtmlContent
+	if htmlContent == "" {
+		htmlContent = "<p>" + strings.ReplaceAll(html.EscapeString(model.AdditionalContent), "\n", "<br>") + "</p>"
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if err := writePart(w, "text/plain; charset=utf-8", model.AdditionalContent); err != nil {
+		return nil, err
+	}
+	if err := writePart(w, "text/html; charset=utf-8", htmlContent); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	header := func(key, value string) {
+		fmt.Fprintf(&msg, "%s: %s\r\n", key, value)
+	}
+	header("From", from.String())
+	header("To", strings.Join(to, ", "))
+	if len(cc) > 0 {
+		header("Cc", strings.Join(cc, ", "))
+	}
+	header("Subject", mime.QEncoding.Encode("utf-8", model.Subject))
+	header("Date", now.Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "multipart/alternative; boundary="+w.Boundary())
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
+
+func writePart(w *multipart.Writer, contentType, content string) error {
+	part, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return err
+	}
+	qp := quotedprintable.NewWriter(part)
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
@@ -0,0 +1,135 @@
+package smtp_mail
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"github.com/nadilas/todo/config"
+	interfaces "github.com/nadilas/todo/if"
+	"github.com/nadilas/todo/todopb"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"time"
+)
+
+// Service sends reminders through the SMTP server in the "smtp" section of the config. Replies with a 5xx code are
+// reported as interfaces.ErrDeliveryRejected, every other failure is transient.
+type Service struct {
+	configProvider config.Provider
+	now            func() time.Time
+}
+
+func NewService(configProvider config.Provider) *Service {
+	return &Service{
+		configProvider: configProvider,
+		now:            time.Now,
+	}
+}
+
+func (s *Service) SendTaskReminder(ctx context.Context, reminderModel *todopb.TaskReminderModel, sender string, addressee ...string) error {
+	if len(addressee) == 0 {
+		return fmt.Errorf("%w: no addressee", interfaces.ErrDeliveryRejected)
+	}
+	message, err := buildMessage(reminderModel, sender, addressee, s.now())
+	if err != nil {
+		return fmt.Errorf("%w: %v", interfaces.ErrDeliveryRejected, err)
+	}
+	// the watchers in cc receive the same message
+	return classify(s.send(ctx, sender, addressee, reminderModel.Cc, message))
+}
+
+// send delivers the message to the addressee and the cc recipients. A cc recipient rejected by the server is left out,
+// only a rejected addressee fails the delivery.
+func (s *Service) send(ctx context.Context, sender string, addressee []string, cc []string, message []byte) error {
+	cfg := s.configProvider.Sub("smtp")
+	address := cfg.GetString("address")
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	deadline := s.now().Add(cfg.GetDuration("timeout"))
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	dialer := &net.Dialer{Deadline: deadline}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(deadline)
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if err := c.Hello(cfg.GetString("helo")); err != nil {
+		return err
+	}
+	if cfg.GetBool("start_tls") {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return errors.New("server does not offer STARTTLS")
+		}
+		err := c.StartTLS(&tls.Config{
+			ServerName:         host,
+			InsecureSkipVerify: cfg.GetBool("insecure_skip_verify"),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if username := cfg.GetString("username"); username != "" {
+		if err := c.Auth(smtp.PlainAuth("", username, cfg.GetString("password"), host)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(sender); err != nil {
+		return err
+	}
+	for _, to := range addressee {
+		if err := c.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	for _, to := range cc {
+		if err := c.Rcpt(to); err != nil && !rejected(err) {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(message); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	// the message was accepted, failing now would send it again on retry
+	_ = c.Quit()
+	return nil
+}
+
+// classify marks permanent SMTP failures, which must not be retried
+func classify(err error) error {
+	if err == nil {
+		return nil
+	}
+	if rejected(err) {
+		return fmt.Errorf("%w: %v", interfaces.ErrDeliveryRejected, err)
+	}
+	return fmt.Errorf("sending reminder: %w", err)
+}
+
+// rejected reports whether the server replied with a permanent failure
+func rejected(err error) bool {
+	var smtpErr *textproto.Error
+	return errors.As(err, &smtpErr) && smtpErr.Code >= 500
+}
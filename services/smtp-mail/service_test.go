@@ -0,0 +1,170 @@
+package smtp_mail_test
+
+import (
+	"context"
+	"errors"
+	"github.com/nadilas/todo/config"
+	interfaces "github.com/nadilas/todo/if"
+	smtp_mail "github.com/nadilas/todo/services/smtp-mail"
+	"github.com/nadilas/todo/todopb"
+	. "github.com/nadilas/todo/workflows/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+var model = &todopb.TaskReminderModel{
+	Subject:           "Reminder from ToDo: buy milk",
+	AdditionalContent: "Don't forget <milk>",
+}
+
+func TestService(t *testing.T) {
+	var server *smtpStandIn
+	var service *smtp_mail.Service
+	var err error
+	replies := map[string]string{
+		"full@example.com":    "452 4.2.2 mailbox full",
+		"unknown@example.com": "550 5.1.1 no such user",
+	}
+
+	Scenario(t, "reminder is sent as multipart text and html",
+		Given(aMailServer(t, &server, &service, "secret", replies)),
+		When(reminderSent(&service, &err, "jane.doe@example.com")),
+		Then(func(t *testing.T) {
+			require.NoError(t, err)
+			delivered := server.delivered()
+			require.Len(t, delivered, 1)
+			assert.Equal(t, "tool@domain.com", delivered[0].from)
+			assert.Equal(t, []string{"jane.doe@example.com"}, delivered[0].to)
+
+			msg, err := mail.ReadMessage(strings.NewReader(delivered[0].data))
+			require.NoError(t, err)
+			subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+			require.NoError(t, err)
+			assert.Equal(t, model.Subject, subject)
+
+			parts := readParts(t, msg)
+			assert.Equal(t, "Don't forget <milk>", parts["text/plain; charset=utf-8"])
+			assert.Equal(t, "<p>Don&#39;t forget &lt;milk&gt;</p>", parts["text/html; charset=utf-8"])
+		}),
+	)
+	Scenario(t, "watchers are sent a copy in cc",
+		Given(aMailServer(t, &server, &service, "secret", replies)),
+		When(func(t *testing.T) {
+			cc := &todopb.TaskReminderModel{Subject: model.Subject, AdditionalContent: model.AdditionalContent, Cc: []string{"watcher@example.com"}}
+			err = service.SendTaskReminder(context.Background(), cc, "tool@domain.com", "jane.doe@example.com")
+		}),
+		Then(func(t *testing.T) {
+			require.NoError(t, err)
+			delivered := server.delivered()
+			require.Len(t, delivered, 1)
+			assert.Equal(t, []string{"jane.doe@example.com", "watcher@example.com"}, delivered[0].to)
+
+			msg, err := mail.ReadMessage(strings.NewReader(delivered[0].data))
+			require.NoError(t, err)
+			assert.Equal(t, "<jane.doe@example.com>", msg.Header.Get("To"))
+			assert.Equal(t, "<watcher@example.com>", msg.Header.Get("Cc"))
+		}),
+	)
+	Scenario(t, "watchers rejected by the server are left out of the delivery",
+		Given(aMailServer(t, &server, &service, "secret", replies)),
+		When(func(t *testing.T) {
+			cc := &todopb.TaskReminderModel{Subject: model.Subject, AdditionalContent: model.AdditionalContent, Cc: []string{"unknown@example.com", "watcher@example.com"}}
+			err = service.SendTaskReminder(context.Background(), cc, "tool@domain.com", "jane.doe@example.com")
+		}),
+		Then(func(t *testing.T) {
+			require.NoError(t, err)
+			delivered := server.delivered()
+			require.Len(t, delivered, 1)
+			assert.Equal(t, []string{"jane.doe@example.com", "watcher@example.com"}, delivered[0].to)
+		}),
+	)
+	Scenario(t, "failing to quit after the message was accepted is ignored",
+		Given(aMailServer(t, &server, &service, "secret", map[string]string{"QUIT": "421 4.3.0 shutting down"})),
+		When(reminderSent(&service, &err, "jane.doe@example.com")),
+		Then(func(t *testing.T) {
+			require.NoError(t, err)
+			assert.Len(t, server.delivered(), 1)
+		}),
+	)
+	Scenario(t, "permanent failure is rejected",
+		Given(aMailServer(t, &server, &service, "secret", replies)),
+		When(reminderSent(&service, &err, "unknown@example.com")),
+		Then(func(t *testing.T) {
+			assert.True(t, errors.Is(err, interfaces.ErrDeliveryRejected), "unexpected error: %v", err)
+			assert.Empty(t, server.delivered())
+		}),
+	)
+	Scenario(t, "transient failure is not rejected",
+		Given(aMailServer(t, &server, &service, "secret", replies)),
+		When(reminderSent(&service, &err, "full@example.com")),
+		Then(func(t *testing.T) {
+			require.Error(t, err)
+			assert.False(t, errors.Is(err, interfaces.ErrDeliveryRejected), "unexpected error: %v", err)
+		}),
+	)
+	Scenario(t, "invalid credentials are rejected",
+		Given(aMailServer(t, &server, &service, "wrong", replies)),
+		When(reminderSent(&service, &err, "jane.doe@example.com")),
+		Then(func(t *testing.T) {
+			assert.True(t, errors.Is(err, interfaces.ErrDeliveryRejected), "unexpected error: %v", err)
+		}),
+	)
+	Scenario(t, "unreachable server is transient",
+		Given(aMailServer(t, &server, &service, "secret", replies)),
+		And(func(t *testing.T) {
+			server.listener.Close()
+		}),
+		When(reminderSent(&service, &err, "jane.doe@example.com")),
+		Then(func(t *testing.T) {
+			require.Error(t, err)
+			assert.False(t, errors.Is(err, interfaces.ErrDeliveryRejected), "unexpected error: %v", err)
+		}),
+	)
+}
+
+func aMailServer(t *testing.T, server **smtpStandIn, service **smtp_mail.Service, password string, replies map[string]string) func(t *testing.T) {
+	return func(st *testing.T) {
+		*server = startSmtpStandIn(t, "todo", "secret", replies)
+		env := map[string]string{
+			"TODO_SMTP_ADDRESS":  (*server).address(),
+			"TODO_SMTP_USERNAME": "todo",
+			"TODO_SMTP_PASSWORD": password,
+		}
+		provider, err := config.New(config.Defaults, "", func(key string) (string, bool) {
+			value, ok := env[key]
+			return value, ok
+		})
+		require.NoError(st, err)
+		*service = smtp_mail.NewService(provider)
+	}
+}
+
+func reminderSent(service **smtp_mail.Service, err *error, addressee ...string) func(t *testing.T) {
+	return func(t *testing.T) {
+		*err = (*service).SendTaskReminder(context.Background(), model, "tool@domain.com", addressee...)
+	}
+}
+
+// readParts returns the decoded parts of a multipart message by content type
+func readParts(t *testing.T, msg *mail.Message) map[string]string {
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	parts := map[string]string{}
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			break
+		}
+		content, err := ioutil.ReadAll(part)
+		require.NoError(t, err)
+		parts[part.Header.Get("Content-Type")] = string(content)
+	}
+	return parts
+}
@@ -0,0 +1,131 @@
+package smtp_mail_test
+
+import (
+	"encoding/base64"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// smtpStandIn is an in-process SMTP server accepting PLAIN auth and recording the delivered messages. Recipients
+// found in replies are answered with the given reply instead of being accepted, like QUIT if replies holds it.
+type smtpStandIn struct {
+	listener net.Listener
+	username string
+	password string
+	replies  map[string]string
+
+	mu       sync.Mutex
+	messages []delivery
+}
+
+type delivery struct {
+	from string
+	to   []string
+	data string
+}
+
+func startSmtpStandIn(t *testing.T, username, password string, replies map[string]string) *smtpStandIn {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &smtpStandIn{
+		listener: listener,
+		username: username,
+		password: password,
+		replies:  replies,
+	}
+	go s.serve()
+	t.Cleanup(func() {
+		listener.Close()
+	})
+	return s
+}
+
+func (s *smtpStandIn) address() string {
+	return s.listener.Addr().String()
+}
+
+func (s *smtpStandIn) delivered() []delivery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]delivery(nil), s.messages...)
+}
+
+func (s *smtpStandIn) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(textproto.NewConn(conn))
+	}
+}
+
+func (s *smtpStandIn) handle(conn *textproto.Conn) {
+	defer conn.Close()
+	var current delivery
+	conn.PrintfLine("220 standin ESMTP")
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch verb {
+		case "EHLO", "HELO":
+			conn.PrintfLine("250-standin")
+			conn.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			credentials, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(line, "AUTH PLAIN "))
+			if string(credentials) == "\x00"+s.username+"\x00"+s.password {
+				conn.PrintfLine("235 2.7.0 authentication successful")
+			} else {
+				conn.PrintfLine("535 5.7.8 authentication failed")
+			}
+		case "MAIL":
+			current = delivery{from: address(line)}
+			conn.PrintfLine("250 2.1.0 ok")
+		case "RCPT":
+			to := address(line)
+			if reply, ok := s.replies[to]; ok {
+				conn.PrintfLine(reply)
+				continue
+			}
+			current.to = append(current.to, to)
+			conn.PrintfLine("250 2.1.5 ok")
+		case "DATA":
+			conn.PrintfLine("354 go ahead")
+			lines, err := conn.ReadDotLines()
+			if err != nil {
+				return
+			}
+			current.data = strings.Join(lines, "\r\n")
+			s.mu.Lock()
+			s.messages = append(s.messages, current)
+			s.mu.Unlock()
+			conn.PrintfLine("250 2.0.0 queued")
+		case "QUIT":
+			if reply, ok := s.replies["QUIT"]; ok {
+				conn.PrintfLine(reply)
+				return
+			}
+			conn.PrintfLine("221 2.0.0 bye")
+			return
+		default:
+			conn.PrintfLine("250 ok")
+		}
+	}
+}
+
+// address returns the address in angle brackets of a MAIL or RCPT command
+func address(line string) string {
+	start, end := strings.Index(line, "<"), strings.Index(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
@@ -0,0 +1,231 @@
+// Package templates renders the reminder emails. Every locale has a <locale>.txt.tmpl file defining the "subject" and
+// "body" templates and a <locale>.html.tmpl file defining the html "body" template. Escalations are rendered with the
+// "<name>_subject" and "<name>_body" templates, the defaults define the manager and group escalations. Digests are
+// rendered with the "digest_subject" and "digest_body" templates. Due dates are notified with the "due_soon_" and
+// "overdue_" templates, completed blockers with the "unblocked_" templates.
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"github.com/nadilas/todo/timeago"
+	"github.com/nadilas/todo/todopb"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// DefaultLocale is used for users whose locale has no templates
+const DefaultLocale = "en"
+
+//go:embed defaults/*.tmpl
+var defaults embed.FS
+
+// Locales maps the supported locales to the timeago config used to render how long a todo has been open
+var Locales = map[string]timeago.Config{
+	"en": timeago.English,
+	"fr": timeago.French,
+	"pt": timeago.Portuguese,
+	"zh": timeago.Chinese,
+}
+
+// Stats are the numbers a reminder reports on
+type Stats struct {
+	// RemindersSent is the number of reminders sent about the todo before
+	RemindersSent int
+	// OtherPending is the number of pending todos besides the one reminded about
+	OtherPending int
+}
+
+// ReminderData is the data the templates are executed with
+type ReminderData struct {
+	Stats
+	Description string
+	DisplayName string
+	// OpenSince is how long the todo has been open, e.g. "3 days ago"
+	OpenSince string
+}
+
+// EscalationData is the data the escalation templates are executed with
+type EscalationData struct {
+	ReminderData
+	// Assignee is the display name of the user the todo is assigned to
+	Assignee string
+}
+
+// DueData is the data the due soon and overdue templates are executed with
+type DueData struct {
+	ReminderData
+	// Due is when the todo is due, e.g. "in 3 hours" or "2 days ago"
+	Due string
+}
+
+// UnblockedData is the data the unblocked templates are executed with
+type UnblockedData struct {
+	ReminderData
+	// Blocker is the description of the completed todo
+	Blocker string
+	// StillBlockedBy is the number of todos still blocking the todo, 0 once it can be started
+	StillBlockedBy int
+}
+
+// DigestItem is a todo listed in a digest
+type DigestItem struct {
+	Description string
+	// OpenSince is how long the todo has been open
+	OpenSince     time.Duration
+	RemindersSent int
+}
+
+// DigestData is the data the digest templates are executed with
+type DigestData struct {
+	DisplayName string
+	// Items are the todos due, their OtherPending is not set
+	Items []ReminderData
+	// OtherPending is the number of pending todos besides the listed ones
+	OtherPending int
+}
+
+type Engine struct {
+	text map[string]*texttemplate.Template
+	html map[string]*htmltemplate.Template
+}
+
+// Load parses the templates of every supported locale from dir, or the embedded defaults if dir is empty
+func Load(dir string) (*Engine, error) {
+	var fsys fs.FS = os.DirFS(dir)
+	if dir == "" {
+		sub, err := fs.Sub(defaults, "defaults")
+		if err != nil {
+			return nil, err
+		}
+		fsys = sub
+	}
+
+	e := &Engine{
+		text: map[string]*texttemplate.Template{},
+		html: map[string]*htmltemplate.Template{},
+	}
+	for locale := range Locales {
+		text, err := texttemplate.ParseFS(fsys, locale+".txt.tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("loading templates: %w", err)
+		}
+		html, err := htmltemplate.ParseFS(fsys, locale+".html.tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("loading templates: %w", err)
+		}
+		e.text[locale] = text
+		e.html[locale] = html
+	}
+	return e, nil
+}
+
+// Render fills the templates of the user's locale
+func (e *Engine) Render(user *todopb.ADUser, description string, openSince time.Duration, stats Stats) (*todopb.TaskReminderModel, error) {
+	locale := Locale(user.GetLocale())
+	return e.render(locale, "", ReminderData{
+		Stats:       stats,
+		Description: description,
+		DisplayName: user.GetDisplayName(),
+		OpenSince:   Locales[locale].FormatRelativeDuration(openSince),
+	})
+}
+
+// RenderEscalation fills the escalation templates with the given name in the recipient's locale
+func (e *Engine) RenderEscalation(name string, recipient, assignee *todopb.ADUser, description string, openSince time.Duration, stats Stats) (*todopb.TaskReminderModel, error) {
+	locale := Locale(recipient.GetLocale())
+	return e.render(locale, name+"_", EscalationData{
+		ReminderData: ReminderData{
+			Stats:       stats,
+			Description: description,
+			DisplayName: recipient.GetDisplayName(),
+			OpenSince:   Locales[locale].FormatRelativeDuration(openSince),
+		},
+		Assignee: assignee.GetDisplayName(),
+	})
+}
+
+// RenderDue fills the due templates with the given name in the user's locale. A negative dueIn renders a todo which
+// is overdue.
+func (e *Engine) RenderDue(name string, user *todopb.ADUser, description string, openSince, dueIn time.Duration, stats Stats) (*todopb.TaskReminderModel, error) {
+	locale := Locale(user.GetLocale())
+	return e.render(locale, name+"_", DueData{
+		ReminderData: ReminderData{
+			Stats:       stats,
+			Description: description,
+			DisplayName: user.GetDisplayName(),
+			OpenSince:   Locales[locale].FormatRelativeDuration(openSince),
+		},
+		Due: Locales[locale].FormatRelativeDuration(-dueIn),
+	})
+}
+
+// RenderUnblocked fills the unblocked templates in the user's locale, telling the blocker of the todo is completed
+func (e *Engine) RenderUnblocked(user *todopb.ADUser, description string, openSince time.Duration, blocker string, stillBlockedBy int, stats Stats) (*todopb.TaskReminderModel, error) {
+	locale := Locale(user.GetLocale())
+	return e.render(locale, "unblocked_", UnblockedData{
+		ReminderData: ReminderData{
+			Stats:       stats,
+			Description: description,
+			DisplayName: user.GetDisplayName(),
+			OpenSince:   Locales[locale].FormatRelativeDuration(openSince),
+		},
+		Blocker:        blocker,
+		StillBlockedBy: stillBlockedBy,
+	})
+}
+
+// RenderDigest fills the digest templates of the user's locale, listing the items
+func (e *Engine) RenderDigest(user *todopb.ADUser, items []DigestItem, otherPending int) (*todopb.TaskReminderModel, error) {
+	locale := Locale(user.GetLocale())
+	data := DigestData{
+		DisplayName:  user.GetDisplayName(),
+		Items:        make([]ReminderData, len(items)),
+		OtherPending: otherPending,
+	}
+	for i, item := range items {
+		data.Items[i] = ReminderData{
+			Stats:       Stats{RemindersSent: item.RemindersSent},
+			Description: item.Description,
+			DisplayName: user.GetDisplayName(),
+			OpenSince:   Locales[locale].FormatRelativeDuration(item.OpenSince),
+		}
+	}
+	return e.render(locale, "digest_", data)
+}
+
+// render executes the subject and body templates whose names start with prefix
+func (e *Engine) render(locale, prefix string, data interface{}) (*todopb.TaskReminderModel, error) {
+	var subject, text, html bytes.Buffer
+	if err := e.text[locale].ExecuteTemplate(&subject, prefix+"subject", data); err != nil {
+		return nil, err
+	}
+	if err := e.text[locale].ExecuteTemplate(&text, prefix+"body", data); err != nil {
+		return nil, err
+	}
+	if err := e.html[locale].ExecuteTemplate(&html, prefix+"body", data); err != nil {
+		return nil, err
+	}
+	return &todopb.TaskReminderModel{
+		Subject:           strings.TrimSpace(subject.String()),
+		AdditionalContent: text.String(),
+		HtmlContent:       html.String(),
+	}, nil
+}
+
+// Locale returns the supported locale matching a language tag like fr-FR or pt_BR, or the DefaultLocale
+func Locale(tag string) string {
+	language := strings.ToLower(tag)
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	if _, ok := Locales[language]; ok {
+		return language
+	}
+	return DefaultLocale
+}
@@ -0,0 +1,254 @@
+package templates_test
+
+import (
+	"github.com/nadilas/todo/templates"
+	"github.com/nadilas/todo/todopb"
+	. "github.com/nadilas/todo/workflows/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRender(t *testing.T) {
+	var engine *templates.Engine
+	var model *todopb.TaskReminderModel
+
+	Scenario(t, "english reminder",
+		Given(embeddedTemplates(&engine)),
+		When(rendered(&engine, &model, "en-US", "buy <milk>")),
+		Then(func(t *testing.T) {
+			assert.Equal(t, "Reminder from ToDo: buy <milk>", model.Subject)
+			assert.Contains(t, model.AdditionalContent, "Hi Jane Doe")
+			assert.Contains(t, model.AdditionalContent, "opened 3 days ago")
+			assert.Contains(t, model.AdditionalContent, "reminded 3 times before, 4 other todos are pending")
+			assert.Contains(t, model.HtmlContent, "<strong>buy &lt;milk&gt;</strong>")
+		}),
+	)
+	Scenario(t, "french reminder",
+		Given(embeddedTemplates(&engine)),
+		When(rendered(&engine, &model, "fr-FR", "acheter du lait")),
+		Then(func(t *testing.T) {
+			assert.Equal(t, "Rappel de ToDo : acheter du lait", model.Subject)
+			assert.Contains(t, model.AdditionalContent, "ouverte il y a 3 jours")
+		}),
+	)
+	Scenario(t, "portuguese reminder",
+		Given(embeddedTemplates(&engine)),
+		When(rendered(&engine, &model, "pt_BR", "comprar leite")),
+		Then(func(t *testing.T) {
+			assert.Equal(t, "Lembrete do ToDo: comprar leite", model.Subject)
+			assert.Contains(t, model.AdditionalContent, "aberta há 3 dias")
+		}),
+	)
+	Scenario(t, "chinese reminder",
+		Given(embeddedTemplates(&engine)),
+		When(rendered(&engine, &model, "zh", "买牛奶")),
+		Then(func(t *testing.T) {
+			assert.Equal(t, "ToDo 提醒：买牛奶", model.Subject)
+			assert.Contains(t, model.AdditionalContent, "3 天前")
+		}),
+	)
+	Scenario(t, "unsupported locale falls back to english",
+		Given(embeddedTemplates(&engine)),
+		When(rendered(&engine, &model, "de-DE", "Milch kaufen")),
+		Then(func(t *testing.T) {
+			assert.Equal(t, "Reminder from ToDo: Milch kaufen", model.Subject)
+		}),
+	)
+	Scenario(t, "templates are loaded from a directory",
+		Given(func(t *testing.T) {
+			dir := t.TempDir()
+			for _, locale := range []string{"en", "fr", "pt", "zh"} {
+				writeFile(t, dir, locale+".txt.tmpl", `{{define "subject"}}[todo] {{.Description}}{{end}}{{define "body"}}{{.OpenSince}}{{end}}`)
+				writeFile(t, dir, locale+".html.tmpl", `{{define "body"}}<b>{{.OpenSince}}</b>{{end}}`)
+			}
+			var err error
+			engine, err = templates.Load(dir)
+			require.NoError(t, err)
+		}),
+		When(rendered(&engine, &model, "en", "buy milk")),
+		Then(func(t *testing.T) {
+			assert.Equal(t, "[todo] buy milk", model.Subject)
+			assert.Equal(t, "3 days ago", model.AdditionalContent)
+			assert.Equal(t, "<b>3 days ago</b>", model.HtmlContent)
+		}),
+	)
+	Scenario(t, "missing locale templates fail the load",
+		Then(func(t *testing.T) {
+			_, err := templates.Load(t.TempDir())
+			assert.Error(t, err)
+		}),
+	)
+}
+
+func TestRenderEscalation(t *testing.T) {
+	var engine *templates.Engine
+	var model *todopb.TaskReminderModel
+
+	Scenario(t, "manager escalation names the assignee",
+		Given(embeddedTemplates(&engine)),
+		When(escalated(&engine, &model, "manager", "en")),
+		Then(func(t *testing.T) {
+			assert.Equal(t, "Escalation from ToDo: approve budget", model.Subject)
+			assert.Contains(t, model.AdditionalContent, "Hi Jane Doe")
+			assert.Contains(t, model.AdditionalContent, "John Roe has not completed the todo \"approve budget\"")
+			assert.Contains(t, model.AdditionalContent, "after 3 reminders")
+			assert.Contains(t, model.HtmlContent, "<strong>approve budget</strong>")
+		}),
+	)
+	Scenario(t, "group escalation in the recipient's locale",
+		Given(embeddedTemplates(&engine)),
+		When(escalated(&engine, &model, "group", "fr-FR")),
+		Then(func(t *testing.T) {
+			assert.Equal(t, "Escalade de ToDo : approve budget", model.Subject)
+			assert.Contains(t, model.AdditionalContent, "la tâche « approve budget » de John Roe")
+		}),
+	)
+	Scenario(t, "unknown escalation template fails",
+		Given(embeddedTemplates(&engine)),
+		Then(func(t *testing.T) {
+			_, err := engine.RenderEscalation("director", &todopb.ADUser{}, &todopb.ADUser{}, "approve budget", time.Hour, templates.Stats{})
+			assert.Error(t, err)
+		}),
+	)
+}
+
+func TestRenderDigest(t *testing.T) {
+	var engine *templates.Engine
+	var model *todopb.TaskReminderModel
+
+	Scenario(t, "digest lists the todos with their age",
+		Given(embeddedTemplates(&engine)),
+		When(func(t *testing.T) {
+			var err error
+			model, err = engine.RenderDigest(&todopb.ADUser{DisplayName: "Jane Doe"}, []templates.DigestItem{
+				{Description: "buy milk", OpenSince: time.Hour * 72},
+				{Description: "call <mom>", OpenSince: time.Hour * 24 * 14},
+			}, 2)
+			require.NoError(t, err)
+		}),
+		Then(func(t *testing.T) {
+			assert.Equal(t, "ToDo digest: 2 todos", model.Subject)
+			assert.Contains(t, model.AdditionalContent, "- buy milk, opened 3 days ago\n- call <mom>, opened 14 days ago\n")
+			assert.Contains(t, model.AdditionalContent, "2 other todos are pending.")
+			assert.Contains(t, model.HtmlContent, "<li><strong>call &lt;mom&gt;</strong>, opened 14 days ago</li>")
+		}),
+	)
+}
+
+func TestRenderDue(t *testing.T) {
+	var engine *templates.Engine
+	var model *todopb.TaskReminderModel
+	user := &todopb.ADUser{DisplayName: "Jane Doe"}
+
+	Scenario(t, "due soon tells when the todo is due",
+		Given(embeddedTemplates(&engine)),
+		When(func(t *testing.T) {
+			var err error
+			model, err = engine.RenderDue("due_soon", user, "file taxes", time.Hour*48, time.Hour*3, templates.Stats{OtherPending: 1})
+			require.NoError(t, err)
+		}),
+		Then(func(t *testing.T) {
+			assert.Equal(t, "Due soon from ToDo: file taxes", model.Subject)
+			assert.Contains(t, model.AdditionalContent, "your todo \"file taxes\", which was opened 2 days ago, is due in 3 hours.")
+			assert.Contains(t, model.HtmlContent, "<strong>file taxes</strong>")
+		}),
+	)
+	Scenario(t, "overdue tells since when the todo is due",
+		Given(embeddedTemplates(&engine)),
+		When(func(t *testing.T) {
+			var err error
+			model, err = engine.RenderDue("overdue", &todopb.ADUser{DisplayName: "Jane Doe", Locale: "pt-BR"}, "file taxes", time.Hour*96, -time.Hour*48, templates.Stats{})
+			require.NoError(t, err)
+		}),
+		Then(func(t *testing.T) {
+			assert.Equal(t, "Tarefa atrasada do ToDo: file taxes", model.Subject)
+			assert.Contains(t, model.AdditionalContent, "venceu há 2 dias")
+		}),
+	)
+}
+
+func TestRenderUnblocked(t *testing.T) {
+	var engine *templates.Engine
+	var model *todopb.TaskReminderModel
+	user := &todopb.ADUser{DisplayName: "Jane Doe"}
+
+	Scenario(t, "unblocked tells the todo can be started",
+		Given(embeddedTemplates(&engine)),
+		When(func(t *testing.T) {
+			var err error
+			model, err = engine.RenderUnblocked(user, "file taxes", time.Hour*48, "collect receipts", 0, templates.Stats{OtherPending: 1})
+			require.NoError(t, err)
+		}),
+		Then(func(t *testing.T) {
+			assert.Equal(t, "Unblocked from ToDo: file taxes", model.Subject)
+			assert.Contains(t, model.AdditionalContent, "\"collect receipts\" is completed. Your todo \"file taxes\", which was opened 2 days ago, can be started now.")
+			assert.Contains(t, model.HtmlContent, "<strong>collect receipts</strong>")
+		}),
+	)
+	Scenario(t, "unblocked tells how many todos still block the todo",
+		Given(embeddedTemplates(&engine)),
+		When(func(t *testing.T) {
+			var err error
+			model, err = engine.RenderUnblocked(&todopb.ADUser{DisplayName: "Jane Doe", Locale: "fr"}, "file taxes", time.Hour*48, "collect receipts", 2, templates.Stats{})
+			require.NoError(t, err)
+		}),
+		Then(func(t *testing.T) {
+			assert.Contains(t, model.AdditionalContent, "est encore bloquée par 2 autres tâches")
+		}),
+	)
+}
+
+func TestLocale(t *testing.T) {
+	assert.Equal(t, "fr", templates.Locale("fr-CA"))
+	assert.Equal(t, "pt", templates.Locale("PT_br"))
+	assert.Equal(t, "en", templates.Locale(""))
+	assert.Equal(t, "en", templates.Locale("tr"))
+}
+
+func embeddedTemplates(engine **templates.Engine) func(t *testing.T) {
+	return func(t *testing.T) {
+		var err error
+		*engine, err = templates.Load("")
+		require.NoError(t, err)
+	}
+}
+
+func rendered(engine **templates.Engine, model **todopb.TaskReminderModel, locale, description string) func(t *testing.T) {
+	return func(t *testing.T) {
+		user := &todopb.ADUser{
+			DisplayName: "Jane Doe",
+			Locale:      locale,
+		}
+		var err error
+		*model, err = (*engine).Render(user, description, time.Hour*72, templates.Stats{
+			RemindersSent: 3,
+			OtherPending:  4,
+		})
+		require.NoError(t, err)
+	}
+}
+
+func escalated(engine **templates.Engine, model **todopb.TaskReminderModel, name, locale string) func(t *testing.T) {
+	return func(t *testing.T) {
+		recipient := &todopb.ADUser{
+			DisplayName: "Jane Doe",
+			Locale:      locale,
+		}
+		assignee := &todopb.ADUser{
+			DisplayName: "John Roe",
+		}
+		var err error
+		*model, err = (*engine).RenderEscalation(name, recipient, assignee, "approve budget", time.Hour*72, templates.Stats{
+			RemindersSent: 3,
+		})
+		require.NoError(t, err)
+	}
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+}
@@ -2,14 +2,32 @@ package todo
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"github.com/nadilas/todo/calendar"
 	"github.com/nadilas/todo/config"
 	interfaces "github.com/nadilas/todo/if"
+	"github.com/nadilas/todo/templates"
 	"github.com/nadilas/todo/todopb"
+	"go.temporal.io/sdk/temporal"
 	"time"
 )
 
+// TasklistStats counts the todos of a Tasklist
+type TasklistStats struct {
+	Pending   int
+	Completed int
+}
+
 type WorkflowData struct {
+	// AssignedSince is the time since the todo was created
 	AssignedSince time.Duration
+	RemindersSent int32
+	// OtherPending is the number of pending todos besides the one reminded about
+	OtherPending int
+	Completed    int
+	// Digest lists the todos of a digest, which is rendered instead of a reminder about a single todo if set
+	Digest []templates.DigestItem
 }
 
 type Activities struct {
@@ -34,30 +52,183 @@ func (a *Activities) FetchUser(
 	ctx context.Context,
 	username string,
 ) (*todopb.ADUser, error) {
-	return &todopb.ADUser{
-		DisplayName:    username,
-		Domain:         "domain",
-		EmailAddress:   username + "@domain.com",
-		SamAccountName: username,
-	}, nil
+	user, err := a.adService.LookupUser(ctx, username)
+	if errors.Is(err, interfaces.ErrUserNotFound) {
+		// retrying won't make the user appear
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "UserNotFound", err)
+	}
+	return user, err
 }
 
+// CollectWorkflowData collects the data a reminder about the item reports on
 func (a *Activities) CollectWorkflowData(
 	ctx context.Context,
-	stepId string,
+	item *todopb.TodoItem,
+	stats TasklistStats,
+) (*WorkflowData, error) {
+	data := &WorkflowData{
+		RemindersSent: item.GetRemindersSent(),
+		OtherPending:  stats.Pending,
+		Completed:     stats.Completed,
+	}
+	if item.GetCompletedAt() == nil && data.OtherPending > 0 {
+		data.OtherPending--
+	}
+	if item.GetCreatedAt() != nil {
+		data.AssignedSince = time.Since(item.CreatedAt.AsTime())
+	}
+	return data, nil
+}
+
+// CollectDigestData collects the data a digest listing the items reports on
+func (a *Activities) CollectDigestData(
+	ctx context.Context,
+	items []*todopb.TodoItem,
+	stats TasklistStats,
 ) (*WorkflowData, error) {
-	return &WorkflowData{
-		AssignedSince: time.Minute * 60,
-	}, nil
+	data := &WorkflowData{
+		OtherPending: stats.Pending,
+		Completed:    stats.Completed,
+		Digest:       make([]templates.DigestItem, len(items)),
+	}
+	for i, item := range items {
+		data.Digest[i] = templates.DigestItem{
+			Description:   item.GetDescription(),
+			RemindersSent: int(item.GetRemindersSent()),
+		}
+		if item.GetCreatedAt() != nil {
+			data.Digest[i].OpenSince = time.Since(item.CreatedAt.AsTime())
+		}
+		if item.GetCompletedAt() == nil && data.OtherPending > 0 {
+			data.OtherPending--
+		}
+	}
+	return data, nil
 }
 
+// PrepareReminderModel renders the reminder templates in the locale of the user, or the digest templates if the data
+// lists a digest. The remark is the description of the todo.
 func (a *Activities) PrepareReminderModel(
 	ctx context.Context,
 	data *WorkflowData,
 	assignedSince time.Duration,
 	remark string,
+	user *todopb.ADUser,
+) (*todopb.TaskReminderModel, error) {
+	return a.render(data, func(engine *templates.Engine, data *WorkflowData, stats templates.Stats) (*todopb.TaskReminderModel, error) {
+		if len(data.Digest) > 0 {
+			return engine.RenderDigest(user, data.Digest, data.OtherPending)
+		}
+		if assignedSince == 0 {
+			assignedSince = data.AssignedSince
+		}
+		return engine.Render(user, remark, assignedSince, stats)
+	})
+}
+
+// FetchEscalationRecipients looks up the users notified at the escalation level about a todo of the assignee
+func (a *Activities) FetchEscalationRecipients(
+	ctx context.Context,
+	level *todopb.EscalationLevel,
+	assignee string,
+) ([]*todopb.ADUser, error) {
+	var recipients []*todopb.ADUser
+	var err error
+	switch level.GetTarget() {
+	case todopb.EscalationLevel_MANAGER:
+		var manager *todopb.ADUser
+		manager, err = a.adService.LookupManager(ctx, assignee)
+		recipients = []*todopb.ADUser{manager}
+	case todopb.EscalationLevel_GROUP:
+		group := level.GetGroup()
+		if group == "" {
+			group = a.configProvider.GetString("escalation.group")
+		}
+		if group == "" {
+			err := errors.New("no escalation group configured")
+			return nil, temporal.NewNonRetryableApplicationError(err.Error(), "NoEscalationGroup", err)
+		}
+		recipients, err = a.adService.ListGroupMembers(ctx, group)
+	default:
+		err := fmt.Errorf("unknown escalation target: %s", level.GetTarget())
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "UnknownEscalationTarget", err)
+	}
+	if errors.Is(err, interfaces.ErrUserNotFound) {
+		// retrying won't make the recipients appear
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "UserNotFound", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return recipients, nil
+}
+
+// PrepareEscalationModel renders the escalation templates with the given name in the locale of the recipient. The
+// remark is the description of the todo.
+func (a *Activities) PrepareEscalationModel(
+	ctx context.Context,
+	data *WorkflowData,
+	template string,
+	remark string,
+	assignee *todopb.ADUser,
+	recipient *todopb.ADUser,
 ) (*todopb.TaskReminderModel, error) {
-	return &todopb.TaskReminderModel{}, nil
+	return a.render(data, func(engine *templates.Engine, data *WorkflowData, stats templates.Stats) (*todopb.TaskReminderModel, error) {
+		return engine.RenderEscalation(template, recipient, assignee, remark, data.AssignedSince, stats)
+	})
+}
+
+// PrepareDueModel renders the due templates with the given name in the locale of the user. The remark is the
+// description of the todo, dueIn is negative once the todo is overdue.
+func (a *Activities) PrepareDueModel(
+	ctx context.Context,
+	data *WorkflowData,
+	template string,
+	remark string,
+	dueIn time.Duration,
+	user *todopb.ADUser,
+) (*todopb.TaskReminderModel, error) {
+	return a.render(data, func(engine *templates.Engine, data *WorkflowData, stats templates.Stats) (*todopb.TaskReminderModel, error) {
+		return engine.RenderDue(template, user, remark, data.AssignedSince, dueIn, stats)
+	})
+}
+
+// PrepareUnblockedModel renders the unblocked templates in the locale of the user. The remark is the description of
+// the todo, the blocker the description of the completed todo blocking it.
+func (a *Activities) PrepareUnblockedModel(
+	ctx context.Context,
+	data *WorkflowData,
+	remark string,
+	blocker string,
+	stillBlockedBy int,
+	user *todopb.ADUser,
+) (*todopb.TaskReminderModel, error) {
+	return a.render(data, func(engine *templates.Engine, data *WorkflowData, stats templates.Stats) (*todopb.TaskReminderModel, error) {
+		return engine.RenderUnblocked(user, remark, data.AssignedSince, blocker, stillBlockedBy, stats)
+	})
+}
+
+// render loads the templates and renders a mail with them, passing the stats of the data. Invalid templates fail the
+// activity without retry, since retrying won't fix them.
+func (a *Activities) render(
+	data *WorkflowData,
+	fn func(engine *templates.Engine, data *WorkflowData, stats templates.Stats) (*todopb.TaskReminderModel, error),
+) (*todopb.TaskReminderModel, error) {
+	if data == nil {
+		data = &WorkflowData{}
+	}
+	engine, err := templates.Load(a.configProvider.GetString("templates.dir"))
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "InvalidTemplates", err)
+	}
+	model, err := fn(engine, data, templates.Stats{
+		RemindersSent: int(data.RemindersSent),
+		OtherPending:  data.OtherPending,
+	})
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "InvalidTemplates", err)
+	}
+	return model, nil
 }
 
 func (a *Activities) SendTaskReminder(
@@ -65,5 +236,23 @@ func (a *Activities) SendTaskReminder(
 	model *todopb.TaskReminderModel,
 	addressee []string,
 ) error {
-	return a.emailService.SendTaskReminder(ctx, model, "tool@domain.com", addressee...)
+	err := a.emailService.SendTaskReminder(ctx, model, a.configProvider.GetString("mail.sender"), addressee...)
+	if errors.Is(err, interfaces.ErrDeliveryRejected) {
+		// the mail server won't accept the reminder on a retry either
+		return temporal.NewNonRetryableApplicationError(err.Error(), "DeliveryRejected", err)
+	}
+	return err
+}
+
+// FetchDeliveryPolicy reads the delivery policy of the owner. Keys under delivery.users.<owner> override the defaults
+// of the delivery section.
+func (a *Activities) FetchDeliveryPolicy(
+	ctx context.Context,
+	owner string,
+) (*calendar.Policy, error) {
+	policy, err := calendar.PolicyFromConfig(DeliveryConfig(a.configProvider, owner))
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "InvalidDeliveryPolicy", err)
+	}
+	return policy, nil
 }
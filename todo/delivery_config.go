@@ -0,0 +1,50 @@
+package todo
+
+import (
+	"github.com/nadilas/todo/config"
+	"time"
+)
+
+// DeliveryConfig returns the delivery section of the owner, falling back to the defaults of the section
+func DeliveryConfig(configProvider config.Provider, owner string) config.Provider {
+	delivery := configProvider.Sub("delivery")
+	if owner == "" {
+		return delivery
+	}
+	return &userOverride{
+		Provider: delivery,
+		user:     delivery.Sub("users." + owner),
+	}
+}
+
+type userOverride struct {
+	config.Provider
+	user config.Provider
+}
+
+func (u *userOverride) section(key string) config.Provider {
+	if u.user.IsSet(key) {
+		return u.user
+	}
+	return u.Provider
+}
+
+func (u *userOverride) GetString(key string) string {
+	return u.section(key).GetString(key)
+}
+
+func (u *userOverride) GetDuration(key string) time.Duration {
+	return u.section(key).GetDuration(key)
+}
+
+func (u *userOverride) GetInt(key string) int {
+	return u.section(key).GetInt(key)
+}
+
+func (u *userOverride) GetBool(key string) bool {
+	return u.section(key).GetBool(key)
+}
+
+func (u *userOverride) IsSet(key string) bool {
+	return u.user.IsSet(key) || u.Provider.IsSet(key)
+}
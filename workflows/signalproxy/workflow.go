@@ -14,6 +14,9 @@ type Payload struct {
 	TargetId   string
 	SignalName string
 	Data       *anypb.Any
+	// SignalDelivered marks the signal as already delivered by the caller, e.g. through signal-with-start. The proxy then
+	// only awaits the completion, which requires the caller to use the proxy's workflow id as CompletionTargetId
+	SignalDelivered bool
 }
 
 // InputData is the input data for the signal. Any signal handler using this proxied method has to unmarshal to this received type
@@ -34,17 +37,19 @@ func SignalProxy(ctx workflow.Context, payload *Payload) (*Result, error) {
 	logger := workflow.GetLogger(ctx)
 	completeChannel := workflow.GetSignalChannel(ctx, CompletedSignal)
 	logger.Debug("Starting to proxy signal data", "targetId", payload.TargetId, "signalName", payload.SignalName)
-	// wrap the target data
-	wi := workflow.GetInfo(ctx)
-	inputData := InputData{
-		CompletionTargetId: wi.WorkflowExecution.ID,
-		Data:               payload.Data,
-	}
-	// target the latest runID
-	err := workflow.SignalExternalWorkflow(ctx, payload.TargetId, "", payload.SignalName, inputData).Get(ctx, nil)
-	if err != nil {
-		logger.Error("Proxy signal failed", "error", err)
-		return nil, err
+	if !payload.SignalDelivered {
+		// wrap the target data
+		wi := workflow.GetInfo(ctx)
+		inputData := InputData{
+			CompletionTargetId: wi.WorkflowExecution.ID,
+			Data:               payload.Data,
+		}
+		// target the latest runID
+		err := workflow.SignalExternalWorkflow(ctx, payload.TargetId, "", payload.SignalName, inputData).Get(ctx, nil)
+		if err != nil {
+			logger.Error("Proxy signal failed", "error", err)
+			return nil, err
+		}
 	}
 	// wait for completion
 	var result *Result
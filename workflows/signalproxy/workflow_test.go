@@ -45,6 +45,19 @@ func (s *TestSignalProxySuite) Test_Basics() {
 		s.Then(completeWithResult(&result)),
 	)
 
+	s.Scenario("proxy awaits signal delivered by caller", s.setupMocks,
+		s.Given(signalDeliveredByCaller(
+			&payload,
+			&result,
+			"targetWorkflow",
+			"approve",
+			durationpb.New(time.Minute*90),
+			durationpb.New(time.Minute*30),
+		)),
+		s.When(sendSignalViaProxy(&payload)),
+		s.Then(completeWithResult(&result)),
+	)
+
 	s.Scenario("proxy cannot signal target workflow", s.setupMocks,
 		s.Given(signalWithFailedProxy(
 			&payload,
@@ -134,6 +147,26 @@ func signalWithSuccessfulProxy(payload **signalproxy.Payload, result **signalpro
 	}
 }
 
+func signalDeliveredByCaller(payload **signalproxy.Payload, result **signalproxy.Result, targetWorkflowId, targetSignalName string, inputData proto.Message, outputData proto.Message) func(st **BDTemporalTestSuite) {
+	return func(st **BDTemporalTestSuite) {
+		*payload = &signalproxy.Payload{
+			TargetId:        targetWorkflowId,
+			SignalName:      targetSignalName,
+			Data:            MustMarshalAny(inputData),
+			SignalDelivered: true,
+		}
+		*result = &signalproxy.Result{
+			Success: true,
+			Data:    MustMarshalAny(outputData),
+		}
+
+		// no signal is sent by the proxy, the target completes the request directly
+		(*st).Env.RegisterDelayedCallback(func() {
+			(*st).Env.SignalWorkflow(signalproxy.CompletedSignal, *result)
+		}, time.Minute)
+	}
+}
+
 func signalWithFailedProxy(payload **signalproxy.Payload, targetWorkflowId, targetSignalName string, inputData proto.Message, expectedErrMsg string) func(st **BDTemporalTestSuite) {
 	return func(st **BDTemporalTestSuite) {
 		any, err := anypb.New(inputData)
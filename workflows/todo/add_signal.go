@@ -1,6 +1,7 @@
 package todo
 
 import (
+	"fmt"
 	"github.com/nadilas/todo/todopb"
 	"github.com/nadilas/todo/workflows/signalproxy"
 	"go.temporal.io/sdk/workflow"
@@ -30,8 +31,78 @@ func (t *Tasks) handleAddTaskSignal(ctx workflow.Context, sel workflow.Selector)
 			return
 		}
 
+		if addRequest.Item == nil {
+			reportSignalError(ctx, r.CompletionTargetId, "todo is missing")
+			return
+		}
+
+		if addRequest.Item.Uuid == "" {
+			reportSignalError(ctx, r.CompletionTargetId, "uuid is missing")
+			return
+		}
+
+		if _, err := t.indexOfTask(addRequest.Item.Uuid); err == nil {
+			reportSignalError(ctx, r.CompletionTargetId, fmt.Sprintf("task exists already: %s", addRequest.Item.Uuid))
+			return
+		}
+
+		if err := validateReminder(addRequest.Item.Reminder); err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+
+		if err := validateEscalation(addRequest.Item); err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+
+		if len(addRequest.Item.Comments) > 0 {
+			reportSignalError(ctx, r.CompletionTargetId, "comments are added on their own once the todo is added")
+			return
+		}
+
+		if err := t.validateSubtask(addRequest.Item); err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+
+		if err := t.validateBlockers(addRequest.Item); err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+
+		if err := validateRecurrence(addRequest.Item); err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+
+		if err := validateAssignee(ctx, addRequest.Item.Assignee); err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+
+		before := t.auditValues()
+		// the state kept by the Tasklist starts over, it can't be set by the client like with an update
+		item := addRequest.Item
+		item.Revision = 1
+		item.RemindersSent, item.ReminderHistory, item.SnoozedUntil = 0, nil, nil
+		item.EscalationLevel, item.EscalationsSent = 0, 0
+		item.DueSoonSent, item.OverdueSent, item.Due = false, false, ""
+		item.Occurrence, item.NextOccurrence = 0, ""
+		if item.Recurrence != "" {
+			item.Occurrence = 1
+		}
+		item.AuditTrail, item.AuditEntriesDropped = nil, 0
+		open := t.openTasks()
 		t.Items = append(t.Items, addRequest.Item)
-		reportSignalSuccess(ctx, r.CompletionTargetId, nil)
+		// a completed subtask may complete its parents
+		t.completeParents(ctx, addRequest.Item)
+		t.recur(ctx, open)
+		t.audit(ctx, before, AddTaskSignal, t.actorOf(addRequest.Owner))
+		resp, _ := anypb.New(&todopb.AddTodoResponse{
+			Item: addRequest.Item,
+		})
+		reportSignalSuccess(ctx, r.CompletionTargetId, resp)
 
 		t.refreshReminders(ctx, sel)
 	}
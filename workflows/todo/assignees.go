@@ -0,0 +1,67 @@
+package todo
+
+import (
+	"errors"
+	"fmt"
+	"github.com/nadilas/todo/todo"
+	"github.com/nadilas/todo/todopb"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// assigneeOf returns the user reminded about the item, its creator until it is reassigned
+func assigneeOf(item *todopb.TodoItem) string {
+	if item.Assignee != "" {
+		return item.Assignee
+	}
+	return item.CreatedBy
+}
+
+// validateAssignee looks the assignee up in the directory, so reminders are not sent to an unknown user
+func validateAssignee(ctx workflow.Context, assignee string) error {
+	if assignee == "" {
+		return nil
+	}
+	act := todo.Activities{}
+	aCtx := workflow.WithActivityOptions(ctx, assigneeActivityOptions)
+	var user *todopb.ADUser
+	err := workflow.ExecuteActivity(
+		aCtx,
+		act.FetchUser,
+		assignee,
+	).Get(ctx, &user)
+	var appErr *temporal.ApplicationError
+	if errors.As(err, &appErr) && appErr.Type() == "UserNotFound" {
+		return fmt.Errorf("unknown assignee %s: %w", assignee, err)
+	}
+	if err != nil {
+		// the directory failed, the assignee may well exist
+		return fmt.Errorf("looking up assignee %s failed: %w", assignee, err)
+	}
+	return nil
+}
+
+// ccWatchers adds the email addresses of the watchers of the item to the cc of the mail. A watcher who could not be
+// fetched is left out.
+func ccWatchers(ctx workflow.Context, item *todopb.TodoItem, mailmodel *todopb.TaskReminderModel) {
+	logger := workflow.GetLogger(ctx)
+	act := todo.Activities{}
+	aCtx := workflow.WithActivityOptions(ctx, reminderActivityOptions)
+	assignee := assigneeOf(item)
+	for _, watcher := range item.Watchers {
+		if watcher == assignee {
+			continue // receives the mail already
+		}
+		var user *todopb.ADUser
+		err := workflow.ExecuteActivity(
+			aCtx,
+			act.FetchUser,
+			watcher,
+		).Get(ctx, &user)
+		if err != nil {
+			logger.Error("Leaving out watcher, fetching user failed", "taskId", item.Uuid, "watcher", watcher, "error", err)
+			continue
+		}
+		mailmodel.Cc = append(mailmodel.Cc, user.EmailAddress)
+	}
+}
@@ -0,0 +1,136 @@
+package todo
+
+import (
+	"fmt"
+	"github.com/nadilas/todo/todopb"
+	"go.temporal.io/sdk/workflow"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxAuditTrail bounds the changes kept on an item, like maxReminderHistory
+const maxAuditTrail = 50
+
+// auditedFields are the fields whose changes are recorded in the audit trail, the state the Tasklist keeps while
+// reminding is left out
+var auditedFields = append(append([]string{}, updatableFields...), "assignee", "snoozedUntil", "nextOccurrence")
+
+// auditValues returns the audited fields of every item by uuid, to find the changes a signal makes
+func (t *Tasks) auditValues() map[string]map[string]string {
+	values := make(map[string]map[string]string, len(t.Items))
+	for _, item := range t.Items {
+		values[item.Uuid] = auditedValues(item)
+	}
+	return values
+}
+
+func auditedValues(item *todopb.TodoItem) map[string]string {
+	m := item.ProtoReflect()
+	values := make(map[string]string, len(auditedFields))
+	for _, name := range auditedFields {
+		fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if m.Has(fd) {
+			values[name] = formatField(fd, m.Get(fd))
+		}
+	}
+	return values
+}
+
+// audit records the changes made to the items since the values were taken in their audit trails, dropping the
+// oldest entries beyond maxAuditTrail. Items added since are recorded with an entry without field.
+func (t *Tasks) audit(ctx workflow.Context, before map[string]map[string]string, signal, actor string) {
+	at := timestamppb.New(workflow.Now(ctx))
+	for _, item := range t.Items {
+		old, found := before[item.Uuid]
+		if !found {
+			appendAudit(item, &todopb.AuditEntry{At: at, Actor: actor, Signal: signal})
+			continue
+		}
+		values := auditedValues(item)
+		for _, name := range auditedFields {
+			if old[name] != values[name] {
+				appendAudit(item, &todopb.AuditEntry{At: at, Actor: actor, Signal: signal, Field: name, From: old[name], To: values[name]})
+			}
+		}
+	}
+}
+
+// appendAudit appends the entry to the audit trail of the item, counting the entries dropped beyond maxAuditTrail
+func appendAudit(item *todopb.TodoItem, entry *todopb.AuditEntry) {
+	item.AuditTrail = append(item.AuditTrail, entry)
+	if excess := len(item.AuditTrail) - maxAuditTrail; excess > 0 {
+		item.AuditTrail = item.AuditTrail[excess:]
+		item.AuditEntriesDropped += int32(excess)
+	}
+}
+
+// queryTaskHistory returns the comments and the audit trail of the todo with the uuid. The trail holds the last
+// maxAuditTrail changes, the history reports how many older ones were dropped.
+func (t *Tasks) queryTaskHistory(uuid string) (*todopb.TodoHistory, error) {
+	idx, err := t.indexOfTask(uuid)
+	if err != nil {
+		return nil, err
+	}
+	return &todopb.TodoHistory{
+		Comments:            t.Items[idx].Comments,
+		AuditTrail:          t.Items[idx].AuditTrail,
+		AuditEntriesDropped: t.Items[idx].AuditEntriesDropped,
+	}, nil
+}
+
+// actorOf returns the user a signal was sent by, the owner of the Tasklist if the request does not tell
+func (t *Tasks) actorOf(owner string) string {
+	if owner != "" {
+		return owner
+	}
+	return t.Owner
+}
+
+// formatField renders the value of a field for the audit trail, e.g. [a, b] for lists, {k=v} for maps and RFC3339
+// for timestamps
+func formatField(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch {
+	case fd.IsList():
+		list := v.List()
+		elements := make([]string, list.Len())
+		for i := range elements {
+			elements[i] = formatValue(fd, list.Get(i))
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case fd.IsMap():
+		var entries []string
+		v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			entries = append(entries, k.String()+"="+formatValue(fd.MapValue(), v))
+			return true
+		})
+		sort.Strings(entries)
+		return "{" + strings.Join(entries, ", ") + "}"
+	default:
+		return formatValue(fd, v)
+	}
+}
+
+func formatValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch {
+	case fd.Enum() != nil:
+		if value := fd.Enum().Values().ByNumber(v.Enum()); value != nil {
+			return string(value.Name())
+		}
+		return fmt.Sprint(v.Enum())
+	case fd.Message() != nil:
+		if ts, ok := v.Message().Interface().(*timestamppb.Timestamp); ok {
+			return ts.AsTime().Format(time.RFC3339)
+		}
+		b, err := protojson.Marshal(v.Message().Interface())
+		if err != nil {
+			return err.Error()
+		}
+		return string(b)
+	default:
+		return v.String()
+	}
+}
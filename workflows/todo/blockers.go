@@ -0,0 +1,147 @@
+package todo
+
+import (
+	"fmt"
+	"github.com/nadilas/todo/todo"
+	"github.com/nadilas/todo/todopb"
+	"go.temporal.io/sdk/workflow"
+)
+
+// unblockedTemplate notifies about a completed blocker, the notifications are recorded in the reminder history with
+// the name of the template
+const unblockedTemplate = "unblocked"
+
+// validateBlockers checks the todos blocking the item exist and don't wait for the item themselves
+func (t *Tasks) validateBlockers(item *todopb.TodoItem) error {
+	for _, uuid := range item.BlockedBy {
+		if uuid == item.Uuid {
+			return fmt.Errorf("invalid blockedBy: %s can't block itself", uuid)
+		}
+		if _, err := t.indexOfTask(uuid); err != nil {
+			return fmt.Errorf("invalid blockedBy: %w", err)
+		}
+		if t.waitsFor(uuid, item) {
+			return fmt.Errorf("invalid blockedBy: %s waits for %s already", uuid, item.Uuid)
+		}
+	}
+	return nil
+}
+
+// waitsFor tells whether the todo with the uuid is blocked by the item, directly or through its blockers. The
+// blockers of the item are taken from the item, which may not be in the Tasklist yet.
+func (t *Tasks) waitsFor(uuid string, item *todopb.TodoItem) bool {
+	visited := map[string]bool{}
+	queue := []string{uuid}
+	for len(queue) > 0 {
+		uuid, queue = queue[0], queue[1:]
+		if visited[uuid] {
+			continue
+		}
+		visited[uuid] = true
+		idx, err := t.indexOfTask(uuid)
+		if err != nil {
+			continue
+		}
+		for _, blocker := range t.Items[idx].BlockedBy {
+			if blocker == item.Uuid {
+				return true
+			}
+			queue = append(queue, blocker)
+		}
+	}
+	return false
+}
+
+// openBlockers returns the uuids of the todos blocking the item which are not completed yet
+func (t *Tasks) openBlockers(item *todopb.TodoItem) []string {
+	var open []string
+	for _, uuid := range item.BlockedBy {
+		idx, err := t.indexOfTask(uuid)
+		if err == nil && t.Items[idx].CompletedAt == nil {
+			open = append(open, uuid)
+		}
+	}
+	return open
+}
+
+// blocked tells whether the item waits for a todo which is not completed yet
+func (t *Tasks) blocked(item *todopb.TodoItem) bool {
+	return len(t.openBlockers(item)) > 0
+}
+
+// queryBlockedTasks returns the pending todos waiting for a todo which is not completed yet
+func (t *Tasks) queryBlockedTasks() ([]*todopb.TodoItem, error) {
+	i := make([]*todopb.TodoItem, 0)
+	for _, item := range t.Items {
+		if item.CompletedAt == nil && t.blocked(item) {
+			i = append(i, item)
+		}
+	}
+	return i, nil
+}
+
+// openTasks returns the uuids of the todos which are not completed, to find the ones completed by a change
+func (t *Tasks) openTasks() map[string]bool {
+	open := map[string]bool{}
+	for _, item := range t.Items {
+		if item.CompletedAt == nil {
+			open[item.Uuid] = true
+		}
+	}
+	return open
+}
+
+// removeBlocker removes the todo with the uuid from the blockers of every todo. Returns whether a todo changed.
+func (t *Tasks) removeBlocker(uuid string) bool {
+	changed := false
+	for _, item := range t.Items {
+		if !contains(item.BlockedBy, uuid) {
+			continue
+		}
+		blockedBy := make([]string, 0, len(item.BlockedBy)-1)
+		for _, blocker := range item.BlockedBy {
+			if blocker != uuid {
+				blockedBy = append(blockedBy, blocker)
+			}
+		}
+		item.BlockedBy = blockedBy
+		item.Revision++
+		changed = true
+	}
+	return changed
+}
+
+// notifyUnblocked notifies about every pending todo blocked by a todo completed since open was taken, whether it can
+// be started or still waits for other todos
+func (t *Tasks) notifyUnblocked(ctx workflow.Context, sel workflow.Selector, open map[string]bool) {
+	for _, blocker := range t.Items {
+		if blocker.CompletedAt == nil || !open[blocker.Uuid] {
+			continue
+		}
+		for _, item := range t.Items {
+			if item.CompletedAt != nil || !contains(item.BlockedBy, blocker.Uuid) {
+				continue
+			}
+			uuid, description, stillBlockedBy := item.Uuid, blocker.Description, len(t.openBlockers(item))
+			t.deliver(ctx, sel, item, func() {
+				// the todo may have changed while the notification was deferred
+				idx, err := t.indexOfTask(uuid)
+				if err != nil || t.Items[idx].CompletedAt != nil {
+					return
+				}
+				t.sendUnblocked(ctx, t.Items[idx], description, stillBlockedBy)
+			}, func() {})
+		}
+	}
+}
+
+// sendUnblocked notifies the assignee of the item that the blocker is completed
+func (t *Tasks) sendUnblocked(ctx workflow.Context, item *todopb.TodoItem, blocker string, stillBlockedBy int) {
+	act := todo.Activities{}
+	t.notify(ctx, item, notification{
+		kind: unblockedTemplate,
+		prepare: func(aCtx workflow.Context, wfdata *todo.WorkflowData, assignee, recipient *todopb.ADUser) workflow.Future {
+			return workflow.ExecuteActivity(aCtx, act.PrepareUnblockedModel, wfdata, item.Description, blocker, stillBlockedBy, recipient)
+		},
+	})
+}
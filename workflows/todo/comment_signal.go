@@ -0,0 +1,82 @@
+package todo
+
+import (
+	"fmt"
+	"github.com/nadilas/todo/todopb"
+	"github.com/nadilas/todo/workflows/signalproxy"
+	"go.temporal.io/sdk/workflow"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strings"
+)
+
+// maxComments bounds the conversation kept on an item. Unlike the reminder history, comments are not dropped, further
+// comments are rejected.
+const maxComments = 200
+
+func (t *Tasks) handleAddCommentSignal(ctx workflow.Context, sel workflow.Selector) func(c workflow.ReceiveChannel, more bool) {
+	return func(c workflow.ReceiveChannel, more bool) {
+		var r *signalproxy.InputData
+
+		c.Receive(ctx, &r)
+		workflow.GetLogger(ctx).Debug("Received add comment signal", "completionId", r.CompletionTargetId)
+
+		if r.CompletionTargetId == "" {
+			workflow.GetLogger(ctx).Warn("Silently ignoring add comment signal with no completionId")
+			return
+		}
+
+		if r.Data == nil {
+			reportSignalError(ctx, r.CompletionTargetId, "comment is missing")
+			return
+		}
+
+		request := &todopb.AddCommentRequest{}
+		if err := r.Data.UnmarshalTo(request); err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+
+		if strings.TrimSpace(request.Text) == "" {
+			reportSignalError(ctx, r.CompletionTargetId, "comment is missing")
+			return
+		}
+
+		idx, err := t.indexOfTask(request.Uuid)
+		if err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+
+		task := t.Items[idx]
+		if len(task.Comments) >= maxComments {
+			reportSignalError(ctx, r.CompletionTargetId, fmt.Sprintf("todo has %d comments already", maxComments))
+			return
+		}
+		author := request.Author
+		if author == "" {
+			author = t.actorOf(request.Owner)
+		}
+		comment := &todopb.Comment{
+			Author: author,
+			Text:   request.Text,
+			At:     timestamppb.New(workflow.Now(ctx)),
+		}
+		task.Comments = append(task.Comments, comment)
+		task.Revision++
+		appendAudit(task, &todopb.AuditEntry{
+			At:     comment.At,
+			Actor:  t.actorOf(request.Owner),
+			Signal: AddCommentSignal,
+			Field:  "comments",
+			To:     comment.Text,
+		})
+
+		workflow.GetLogger(ctx).Debug("Commented task", "atIndex", idx, "taskId", request.Uuid, "author", author)
+		resp, _ := anypb.New(&todopb.AddCommentResponse{
+			Comment:  comment,
+			Revision: task.Revision,
+		})
+		reportSignalSuccess(ctx, r.CompletionTargetId, resp)
+	}
+}
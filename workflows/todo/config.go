@@ -0,0 +1,45 @@
+package todo
+
+import (
+	"github.com/nadilas/todo/config"
+	"go.temporal.io/sdk/workflow"
+	"time"
+)
+
+// reminderActivityOptions are used by the activities sending a reminder
+var reminderActivityOptions = workflow.ActivityOptions{
+	ScheduleToCloseTimeout: time.Hour * 2,
+	StartToCloseTimeout:    time.Minute * 1,
+}
+
+// assigneeActivityOptions are used by the activity looking up a new assignee, which the sender of the signal waits for
+var assigneeActivityOptions = workflow.ActivityOptions{
+	ScheduleToCloseTimeout: time.Second * 30,
+}
+
+// dueSoonBefore is how long before its due date a todo is notified as due soon, recorded by a Tasklist when it starts
+var dueSoonBefore = time.Hour * 24
+
+// initDueSoonBefore records dueSoonBefore in the history of the run unless it was carried over from the last run
+func (t *Tasks) initDueSoonBefore(ctx workflow.Context) {
+	if t.DueSoonBefore > 0 {
+		return
+	}
+	_ = workflow.SideEffect(ctx, func(ctx workflow.Context) interface{} {
+		return dueSoonBefore
+	}).Get(&t.DueSoonBefore)
+}
+
+// Configure applies the "reminder" section of the configuration. It must be called before the worker is started, as
+// workflows replaying with different options would be non-deterministic.
+func Configure(configProvider config.Provider) {
+	reminder := configProvider.Sub("reminder")
+	reminderActivityOptions = workflow.ActivityOptions{
+		ScheduleToCloseTimeout: reminder.GetDuration("schedule_to_close_timeout"),
+		StartToCloseTimeout:    reminder.GetDuration("start_to_close_timeout"),
+	}
+	assigneeActivityOptions = workflow.ActivityOptions{
+		ScheduleToCloseTimeout: reminder.GetDuration("assignee_lookup_timeout"),
+	}
+	dueSoonBefore = reminder.GetDuration("due_soon_before")
+}
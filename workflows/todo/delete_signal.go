@@ -34,20 +34,43 @@ func (t *Tasks) handleDeleteTaskSignal(ctx workflow.Context, sel workflow.Select
 			reportSignalError(ctx, r.CompletionTargetId, err.Error())
 			return
 		}
-		// remove task entirely
-		t.Items = append(t.Items[0:idx], t.Items[idx+1:]...)
-		workflow.GetLogger(ctx).Debug("Deleted task", "atIndex", idx, "taskId", request.Uuid)
+		if err := checkRevision(t.Items[idx], request.ExpectedRevision); err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+		// remove task entirely, with its subtasks
+		before := t.auditValues()
+		open := t.openTasks()
+		deleted := t.Items[idx]
+		unblocked := false
+		for _, uuid := range t.withDescendants(request.Uuid) {
+			idx, _ := t.indexOfTask(uuid)
+			t.Items = append(t.Items[0:idx], t.Items[idx+1:]...)
+			workflow.GetLogger(ctx).Debug("Deleted task", "atIndex", idx, "taskId", uuid)
+			t.cancelReminder(ctx, uuid)
+			unblocked = t.removeBlocker(uuid) || unblocked
+		}
+		// the deleted todo may have been the last open subtask of its parent, or have blocked other todos
+		changed := t.completeParents(ctx, deleted) || unblocked
+		changed = t.recur(ctx, open) || changed
+		t.audit(ctx, before, DeleteTaskSignal, t.actorOf(request.Owner))
 		reportSignalSuccess(ctx, r.CompletionTargetId, nil)
 
-		// cleanup reminder
-		ridx, err := t.indexOfReminder(request.Uuid)
-		if err != nil {
-			return
+		if changed {
+			t.refreshReminders(ctx, sel)
 		}
-		// cancel previous reminder before setting up new one
-		workflow.GetLogger(ctx).Info("Cancelling reminder context", "taskId", request.Uuid)
-		rem := t.reminders[idx]
-		rem.cancelFn()
-		t.reminders = append(t.reminders[0:ridx], t.reminders[ridx+1:]...)
+		t.notifyUnblocked(ctx, sel, open)
+	}
+}
+
+// cancelReminder cancels the timers of the todo with the uuid, if it has any
+func (t *Tasks) cancelReminder(ctx workflow.Context, uuid string) {
+	ridx, err := t.indexOfReminder(uuid)
+	if err != nil {
+		return
 	}
+	workflow.GetLogger(ctx).Info("Cancelling reminder context", "taskId", uuid)
+	rem := t.reminders[ridx]
+	rem.cancelFn()
+	t.reminders = append(t.reminders[0:ridx], t.reminders[ridx+1:]...)
 }
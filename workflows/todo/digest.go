@@ -0,0 +1,185 @@
+package todo
+
+import (
+	"fmt"
+	"github.com/nadilas/todo/todo"
+	"github.com/nadilas/todo/todopb"
+	"github.com/nadilas/todo/workflows/signalproxy"
+	"go.temporal.io/sdk/workflow"
+	"google.golang.org/protobuf/types/known/anypb"
+	"time"
+)
+
+func (t *Tasks) handleSetDigestSignal(ctx workflow.Context, sel workflow.Selector) func(c workflow.ReceiveChannel, more bool) {
+	return func(c workflow.ReceiveChannel, more bool) {
+		var r *signalproxy.InputData
+
+		c.Receive(ctx, &r)
+		workflow.GetLogger(ctx).Debug("Received set digest signal", "completionId", r.CompletionTargetId)
+
+		if r.CompletionTargetId == "" {
+			workflow.GetLogger(ctx).Warn("Silently ignoring set digest signal with no completionId")
+			return
+		}
+
+		if r.Data == nil {
+			reportSignalError(ctx, r.CompletionTargetId, "digest preference is not defined")
+			return
+		}
+
+		request := &todopb.SetDigestRequest{}
+		if err := r.Data.UnmarshalTo(request); err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+
+		if request.Digest != nil {
+			window, err := time.ParseDuration(request.Digest.Window)
+			if err != nil {
+				reportSignalError(ctx, r.CompletionTargetId, fmt.Sprintf("invalid digest window: %s", err))
+				return
+			}
+			if window <= 0 {
+				reportSignalError(ctx, r.CompletionTargetId, fmt.Sprintf("invalid digest window: %s is not positive", request.Digest.Window))
+				return
+			}
+		}
+
+		// an open window is still sent when the digest is turned off
+		t.Digest = request.Digest
+		workflow.GetLogger(ctx).Debug("Set digest preference", "digest", t.Digest)
+		resp, _ := anypb.New(&todopb.SetDigestResponse{
+			Digest: t.Digest,
+		})
+		reportSignalSuccess(ctx, r.CompletionTargetId, resp)
+	}
+}
+
+// collectDigest adds the item to the open digest window, opening one if there is none
+func (t *Tasks) collectDigest(ctx workflow.Context, sel workflow.Selector, item *todopb.TodoItem) {
+	for _, uuid := range t.DigestItems {
+		if uuid == item.Uuid {
+			return
+		}
+	}
+	t.DigestItems = append(t.DigestItems, item.Uuid)
+	if !t.DigestDueAt.IsZero() {
+		return
+	}
+
+	window, err := time.ParseDuration(t.Digest.Window)
+	if err != nil {
+		return // rejected when the preference was set
+	}
+	t.DigestDueAt = workflow.Now(ctx).Add(window)
+	workflow.GetLogger(ctx).Info("Opened digest window", "until", t.DigestDueAt)
+	t.refreshDigest(ctx, sel)
+}
+
+// refreshDigest sets a timer closing the open digest window. The timer outlives the reminder which opened the window.
+func (t *Tasks) refreshDigest(ctx workflow.Context, sel workflow.Selector) {
+	if t.DigestDueAt.IsZero() {
+		return
+	}
+	dCtx, _ := workflow.NewDisconnectedContext(ctx)
+	timer := workflow.NewTimer(dCtx, t.DigestDueAt.Sub(workflow.Now(dCtx)))
+	sel.AddFuture(timer, func(f workflow.Future) {
+		t.deliver(dCtx, sel, nil, func() {
+			t.sendDigests(dCtx)
+		}, func() {
+			t.DigestItems = nil
+			t.DigestDueAt = time.Time{}
+		})
+	})
+}
+
+// sendDigests sends a digest of the pending todos of the window to each of their assignees
+func (t *Tasks) sendDigests(ctx workflow.Context) {
+	var assignees []string
+	itemsOf := map[string][]*todopb.TodoItem{}
+	for _, uuid := range t.DigestItems {
+		idx, err := t.indexOfTask(uuid)
+		if err != nil || t.Items[idx].CompletedAt != nil {
+			continue // deleted or completed within the window
+		}
+		item := t.Items[idx]
+		assignee := assigneeOf(item)
+		if _, ok := itemsOf[assignee]; !ok {
+			assignees = append(assignees, assignee)
+		}
+		itemsOf[assignee] = append(itemsOf[assignee], item)
+	}
+	for _, assignee := range assignees {
+		t.sendDigest(ctx, assignee, itemsOf[assignee])
+	}
+}
+
+// sendDigest runs the activities sending one digest about the items to their assignee. A failing activity skips the
+// digest.
+func (t *Tasks) sendDigest(ctx workflow.Context, assignee string, items []*todopb.TodoItem) {
+	logger := workflow.GetLogger(ctx)
+	act := todo.Activities{}
+	aCtx := workflow.WithActivityOptions(ctx, reminderActivityOptions)
+	// get user
+	var user *todopb.ADUser
+	err := workflow.ExecuteActivity(
+		aCtx,
+		act.FetchUser,
+		assignee,
+	).Get(ctx, &user)
+	if err != nil {
+		logger.Error("Skipping digest, fetching user failed", "user", assignee, "error", err)
+		recordDigestAttempt(ctx, items, assignee, err)
+		return
+	}
+	// get workflow data
+	var wfdata *todo.WorkflowData
+	err = workflow.ExecuteActivity(
+		aCtx,
+		act.CollectDigestData,
+		items,
+		t.stats(),
+	).Get(ctx, &wfdata)
+	if err != nil {
+		logger.Error("Skipping digest, collecting digest data failed", "user", assignee, "recipient", user.EmailAddress, "error", err)
+		recordDigestAttempt(ctx, items, user.EmailAddress, err)
+		return
+	}
+	// get mail data model
+	var mailmodel *todopb.TaskReminderModel
+	err = workflow.ExecuteActivity(
+		aCtx,
+		act.PrepareReminderModel,
+		wfdata,
+		time.Duration(0),
+		"",
+		user,
+	).Get(ctx, &mailmodel)
+	if err != nil {
+		logger.Error("Skipping digest, preparing the digest failed", "user", assignee, "recipient", user.EmailAddress, "error", err)
+		recordDigestAttempt(ctx, items, user.EmailAddress, err)
+		return
+	}
+	// send mail
+	err = workflow.ExecuteActivity(
+		aCtx,
+		act.SendTaskReminder,
+		mailmodel,
+		[]string{user.EmailAddress},
+	).Get(ctx, nil)
+	recordDigestAttempt(ctx, items, user.EmailAddress, err)
+	if err != nil {
+		logger.Error("Sending digest failed", "user", assignee, "recipient", user.EmailAddress, "error", err)
+		return
+	}
+	for _, item := range items {
+		item.RemindersSent++
+	}
+}
+
+// recordDigestAttempt records the digest attempt on each of the merged items.
+func recordDigestAttempt(ctx workflow.Context, items []*todopb.TodoItem, recipient string, err error) {
+	for _, item := range items {
+		recordAttempt(ctx, item, digestAttempt, recipient, err)
+	}
+}
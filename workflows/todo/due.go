@@ -0,0 +1,91 @@
+package todo
+
+import (
+	"github.com/nadilas/todo/timeago"
+	"github.com/nadilas/todo/todo"
+	"github.com/nadilas/todo/todopb"
+	"go.temporal.io/sdk/workflow"
+	"google.golang.org/protobuf/proto"
+)
+
+// the due templates, due notifications are recorded in the reminder history with the name of their template
+const (
+	dueSoonTemplate = "due_soon"
+	overdueTemplate = "overdue"
+)
+
+// dueFormat renders how a todo is due relative to the query, e.g. "due in 3 hours" or "2 days overdue"
+var dueFormat = func() timeago.Config {
+	format := timeago.NoMax(timeago.English)
+	format.FuturePrefix = "due in "
+	format.PastSuffix = " overdue"
+	return format
+}()
+
+// initDue sets a timer for the next due notification of the item, which is renewed until the overdue notification
+// was sent. The due soon notification is skipped if the todo is overdue already.
+func (t *Tasks) initDue(ctx workflow.Context, sel workflow.Selector, item *todopb.TodoItem) {
+	if item.OverdueSent {
+		return
+	}
+	now := workflow.Now(ctx)
+	dueAt := item.DueAt.AsTime()
+	template, at := overdueTemplate, dueAt
+	if !item.DueSoonSent && now.Before(dueAt) {
+		template, at = dueSoonTemplate, dueAt.Add(-t.DueSoonBefore)
+	}
+	if at.Before(now) {
+		at = now
+	}
+	workflow.GetLogger(ctx).Info("notifying due date at", "taskId", item.Uuid, "at", at, "now", now, "template", template)
+
+	timer := workflow.NewTimer(ctx, at.Sub(now))
+	sel.AddFuture(timer, func(f workflow.Future) {
+		t.deliver(ctx, sel, item, func() {
+			if template == dueSoonTemplate && !workflow.Now(ctx).Before(dueAt) {
+				return // deferred past the due date, the overdue notification follows
+			}
+			t.sendDue(ctx, item, template)
+		}, func() {
+			if template == dueSoonTemplate {
+				item.DueSoonSent = true
+			} else {
+				item.OverdueSent = true
+			}
+			t.initDue(ctx, sel, item)
+		})
+	})
+}
+
+// sendDue notifies the assignee of the item with the due template
+func (t *Tasks) sendDue(ctx workflow.Context, item *todopb.TodoItem, template string) {
+	act := todo.Activities{}
+	t.notify(ctx, item, notification{
+		kind: template,
+		prepare: func(aCtx workflow.Context, wfdata *todo.WorkflowData, assignee, recipient *todopb.ADUser) workflow.Future {
+			dueIn := item.DueAt.AsTime().Sub(workflow.Now(ctx))
+			return workflow.ExecuteActivity(aCtx, act.PrepareDueModel, wfdata, template, item.Description, dueIn, recipient)
+		},
+	})
+}
+
+// withDue wraps a query, setting the due text of the returned todos relative to workflow.Now. The todos of the
+// Tasklist are not modified.
+func withDue(ctx workflow.Context, query func() ([]*todopb.TodoItem, error)) func() ([]*todopb.TodoItem, error) {
+	return func() ([]*todopb.TodoItem, error) {
+		items, err := query()
+		if err != nil {
+			return nil, err
+		}
+		now := workflow.Now(ctx)
+		result := make([]*todopb.TodoItem, len(items))
+		for i, item := range items {
+			result[i] = item
+			if item.DueAt != nil {
+				result[i] = proto.Clone(item).(*todopb.TodoItem)
+				result[i].Due = dueFormat.FormatReference(item.DueAt.AsTime(), now)
+			}
+		}
+		return result, nil
+	}
+}
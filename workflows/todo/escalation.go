@@ -0,0 +1,114 @@
+package todo
+
+import (
+	"errors"
+	"fmt"
+	"github.com/nadilas/todo/todo"
+	"github.com/nadilas/todo/todopb"
+	"go.temporal.io/sdk/workflow"
+	"strings"
+	"time"
+)
+
+// validateEscalation rejects escalations which could never be reached or never notify
+func validateEscalation(item *todopb.TodoItem) error {
+	if item.Escalation == nil {
+		return nil
+	}
+	if item.Reminder == nil {
+		return errors.New("invalid escalation: escalating requires a reminder")
+	}
+	if len(item.Escalation.Levels) == 0 {
+		return errors.New("invalid escalation: no levels")
+	}
+	for i, level := range item.Escalation.Levels {
+		if level.After <= 0 {
+			return fmt.Errorf("invalid escalation level %d: after %d is not positive", i+1, level.After)
+		}
+		every, err := time.ParseDuration(level.Every)
+		if err != nil {
+			return fmt.Errorf("invalid escalation level %d every: %w", i+1, err)
+		}
+		if every <= 0 {
+			return fmt.Errorf("invalid escalation level %d every: %s is not positive", i+1, level.Every)
+		}
+	}
+	return nil
+}
+
+// escalate reaches the first escalation level once the assignee was reminded often enough without completing the item
+func (t *Tasks) escalate(ctx workflow.Context, sel workflow.Selector, item *todopb.TodoItem) {
+	if item.Escalation == nil || item.EscalationLevel > 0 || item.CompletedAt != nil {
+		return
+	}
+	if item.RemindersSent < item.Escalation.Levels[0].After {
+		return
+	}
+	t.reachEscalationLevel(ctx, sel, item, 1)
+}
+
+// reachEscalationLevel notifies the recipients of the level right away and then with the cadence of the level
+func (t *Tasks) reachEscalationLevel(ctx workflow.Context, sel workflow.Selector, item *todopb.TodoItem, level int32) {
+	workflow.GetLogger(ctx).Info("Escalating todo", "taskId", item.Uuid, "level", level)
+	item.EscalationLevel = level
+	item.EscalationsSent = 0
+	t.notifyEscalation(ctx, sel, item)
+}
+
+// notifyEscalation notifies the recipients of the current level and moves on to the next level once it is due
+func (t *Tasks) notifyEscalation(ctx workflow.Context, sel workflow.Selector, item *todopb.TodoItem) {
+	if ctx.Err() != nil || item.CompletedAt != nil {
+		return
+	}
+	t.sendEscalation(ctx, item)
+
+	levels := item.Escalation.Levels
+	if int(item.EscalationLevel) < len(levels) && item.EscalationsSent >= levels[item.EscalationLevel].After {
+		t.reachEscalationLevel(ctx, sel, item, item.EscalationLevel+1)
+		return
+	}
+	t.scheduleEscalation(ctx, sel, item)
+}
+
+// scheduleEscalation sets a timer for the next notification of the current level
+func (t *Tasks) scheduleEscalation(ctx workflow.Context, sel workflow.Selector, item *todopb.TodoItem) {
+	if item.Escalation == nil || int(item.EscalationLevel) > len(item.Escalation.Levels) {
+		return // the escalation was removed by an update
+	}
+	every, err := time.ParseDuration(item.Escalation.Levels[item.EscalationLevel-1].Every)
+	if err != nil {
+		return // rejected when the todo was added or updated
+	}
+	sel.AddFuture(workflow.NewTimer(ctx, every), func(f workflow.Future) {
+		t.deliver(ctx, sel, item, func() {
+			t.notifyEscalation(ctx, sel, item)
+		}, func() {})
+	})
+}
+
+// escalationTemplate returns the name of the templates the level is rendered with
+func escalationTemplate(level *todopb.EscalationLevel) string {
+	if level.Template != "" {
+		return level.Template
+	}
+	return strings.ToLower(level.Target.String())
+}
+
+// sendEscalation notifies the recipients of the current level. The level counts as notified once its recipients
+// were reached, even if sending to them failed.
+func (t *Tasks) sendEscalation(ctx workflow.Context, item *todopb.TodoItem) {
+	level := item.Escalation.Levels[item.EscalationLevel-1]
+	act := todo.Activities{}
+	_, reached := t.notify(ctx, item, notification{
+		kind: escalationAttempt,
+		prepare: func(aCtx workflow.Context, wfdata *todo.WorkflowData, assignee, recipient *todopb.ADUser) workflow.Future {
+			return workflow.ExecuteActivity(aCtx, act.PrepareEscalationModel, wfdata, escalationTemplate(level), item.Description, assignee, recipient)
+		},
+		recipients: func(aCtx workflow.Context) workflow.Future {
+			return workflow.ExecuteActivity(aCtx, act.FetchEscalationRecipients, level, assigneeOf(item))
+		},
+	})
+	if reached {
+		item.EscalationsSent++
+	}
+}
@@ -0,0 +1,115 @@
+package todo
+
+import (
+	"fmt"
+	"github.com/nadilas/todo/todopb"
+	"strings"
+)
+
+// LabelSelector selects todos by their labels, see ParseLabelSelector
+type LabelSelector []labelRequirement
+
+type labelRequirement struct {
+	key string
+	// value is compared if set, otherwise only the presence of the key
+	value   *string
+	negated bool
+}
+
+// ParseLabelSelector parses comma separated label requirements: key=value, key!=value, key (the label is set) or
+// !key (the label is not set). An empty selector selects every todo.
+func ParseLabelSelector(selector string) (LabelSelector, error) {
+	if strings.TrimSpace(selector) == "" {
+		return nil, nil
+	}
+	var requirements LabelSelector
+	for _, part := range strings.Split(selector, ",") {
+		part = strings.TrimSpace(part)
+		var r labelRequirement
+		switch {
+		case strings.HasPrefix(part, "!"):
+			r = labelRequirement{key: part[1:], negated: true}
+		case strings.Contains(part, "!="):
+			kv := strings.SplitN(part, "!=", 2)
+			r = labelRequirement{key: kv[0], value: &kv[1], negated: true}
+		case strings.Contains(part, "="):
+			kv := strings.SplitN(part, "=", 2)
+			r = labelRequirement{key: kv[0], value: &kv[1]}
+		default:
+			r = labelRequirement{key: part}
+		}
+		r.key = strings.TrimSpace(r.key)
+		if r.key == "" || strings.ContainsAny(r.key, "!=") {
+			return nil, fmt.Errorf("invalid label selector: %q has no valid key", part)
+		}
+		if r.value != nil {
+			value := strings.TrimSpace(*r.value)
+			r.value = &value
+		}
+		requirements = append(requirements, r)
+	}
+	return requirements, nil
+}
+
+// Matches tells whether the labels meet every requirement of the selector
+func (s LabelSelector) Matches(labels map[string]string) bool {
+	for _, r := range s {
+		value, ok := labels[r.key]
+		matched := ok
+		if r.value != nil {
+			matched = ok && value == *r.value
+		}
+		if matched == r.negated {
+			return false
+		}
+	}
+	return true
+}
+
+// queryFilteredTasks returns the todos matching the filter, the pending ones unless it includes completed todos
+func (t *Tasks) queryFilteredTasks(filter *todopb.TodoFilter) ([]*todopb.TodoItem, error) {
+	selector, err := ParseLabelSelector(filter.GetLabelSelector())
+	if err != nil {
+		return nil, err
+	}
+	i := make([]*todopb.TodoItem, 0)
+	for _, item := range t.Items {
+		if item.CompletedAt != nil && !filter.GetIncludeCompleted() {
+			continue
+		}
+		if hasPriority(item, filter.GetPriorities()) && hasTags(item, filter.GetTags()) && selector.Matches(item.Labels) {
+			i = append(i, item)
+		}
+	}
+	return i, nil
+}
+
+// hasPriority tells whether the item has any of the priorities, or priorities is empty
+func hasPriority(item *todopb.TodoItem, priorities []todopb.TodoItem_Priority) bool {
+	if len(priorities) == 0 {
+		return true
+	}
+	for _, priority := range priorities {
+		if item.Priority == priority {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTags tells whether the item has every one of the tags
+func hasTags(item *todopb.TodoItem, tags []string) bool {
+	for _, tag := range tags {
+		found := false
+		for _, itemTag := range item.Tags {
+			if itemTag == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
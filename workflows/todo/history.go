@@ -0,0 +1,39 @@
+package todo
+
+import (
+	"github.com/nadilas/todo/todopb"
+	"go.temporal.io/sdk/workflow"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxReminderHistory bounds the attempts kept on an item, so the history does not grow the workflow state
+	// across continue-as-new.
+	maxReminderHistory = 20
+	emailChannel       = "email"
+	reminderAttempt    = "reminder"
+	escalationAttempt  = "escalation"
+	digestAttempt      = "digest"
+)
+
+// recordAttempt appends a delivery attempt to the history of the item, dropping the oldest attempts beyond
+// maxReminderHistory. Attempts interrupted by cancelling the reminder are not recorded.
+func recordAttempt(ctx workflow.Context, item *todopb.TodoItem, kind, recipient string, err error) {
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+	attempt := &todopb.ReminderAttempt{
+		At:        timestamppb.New(workflow.Now(ctx)),
+		Channel:   emailChannel,
+		Recipient: recipient,
+		Kind:      kind,
+		Success:   err == nil,
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+	item.ReminderHistory = append(item.ReminderHistory, attempt)
+	if excess := len(item.ReminderHistory) - maxReminderHistory; excess > 0 {
+		item.ReminderHistory = item.ReminderHistory[excess:]
+	}
+}
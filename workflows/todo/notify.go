@@ -0,0 +1,89 @@
+package todo
+
+import (
+	"github.com/nadilas/todo/todo"
+	"github.com/nadilas/todo/todopb"
+	"go.temporal.io/sdk/workflow"
+)
+
+// notification describes a mail about a todo sent by notify
+type notification struct {
+	// kind is recorded in the reminder history of the todo, e.g. reminder or due_soon
+	kind string
+	// prepare executes the activity rendering the mail to the recipient about a todo of the assignee
+	prepare func(aCtx workflow.Context, wfdata *todo.WorkflowData, assignee, recipient *todopb.ADUser) workflow.Future
+	// recipients executes the activity looking up the users notified instead of the assignee. The assignee is
+	// notified with the watchers in cc if nil.
+	recipients func(aCtx workflow.Context) workflow.Future
+}
+
+// notify runs the activities sending the notification about the item: fetching the assignee and the recipients,
+// collecting the workflow data, then preparing and sending a mail to each recipient. Every attempt is recorded in the
+// history of the item. A failing activity skips the notification, or the recipient it failed for. Returns the number
+// of mails sent, and false if the notification was skipped before any recipient was reached.
+func (t *Tasks) notify(ctx workflow.Context, item *todopb.TodoItem, n notification) (int, bool) {
+	logger := workflow.GetLogger(ctx)
+	act := todo.Activities{}
+	aCtx := workflow.WithActivityOptions(ctx, reminderActivityOptions)
+	// get users
+	var assignee *todopb.ADUser
+	err := workflow.ExecuteActivity(
+		aCtx,
+		act.FetchUser,
+		assigneeOf(item),
+	).Get(ctx, &assignee)
+	if err != nil {
+		logger.Error("Skipping notification, fetching user failed", "taskId", item.Uuid, "kind", n.kind, "recipient", assigneeOf(item), "error", err)
+		recordAttempt(ctx, item, n.kind, assigneeOf(item), err)
+		return 0, false
+	}
+	recipients := []*todopb.ADUser{assignee}
+	if n.recipients != nil {
+		recipients = nil
+		if err := n.recipients(aCtx).Get(ctx, &recipients); err != nil {
+			logger.Error("Skipping notification, fetching recipients failed", "taskId", item.Uuid, "kind", n.kind, "error", err)
+			recordAttempt(ctx, item, n.kind, "", err)
+			return 0, false
+		}
+	}
+	// get workflow data
+	var wfdata *todo.WorkflowData
+	err = workflow.ExecuteActivity(
+		aCtx,
+		act.CollectWorkflowData,
+		item,
+		t.stats(),
+	).Get(ctx, &wfdata)
+	if err != nil {
+		logger.Error("Skipping notification, collecting workflow data failed", "taskId", item.Uuid, "kind", n.kind, "error", err)
+		recordAttempt(ctx, item, n.kind, assignee.EmailAddress, err)
+		return 0, false
+	}
+	sent := 0
+	for _, recipient := range recipients {
+		// get mail data model
+		var mailmodel *todopb.TaskReminderModel
+		if err := n.prepare(aCtx, wfdata, assignee, recipient).Get(ctx, &mailmodel); err != nil {
+			logger.Error("Skipping notification, preparing the mail failed", "taskId", item.Uuid, "kind", n.kind, "recipient", recipient.EmailAddress, "error", err)
+			recordAttempt(ctx, item, n.kind, recipient.EmailAddress, err)
+			continue
+		}
+		if n.recipients == nil {
+			ccWatchers(ctx, item, mailmodel)
+		}
+		// send mail
+		err := workflow.ExecuteActivity(
+			aCtx,
+			act.SendTaskReminder,
+			mailmodel,
+			[]string{recipient.EmailAddress},
+		).Get(ctx, nil)
+		recordAttempt(ctx, item, n.kind, recipient.EmailAddress, err)
+		if err != nil {
+			logger.Error("Sending notification failed", "taskId", item.Uuid, "kind", n.kind, "recipient", recipient.EmailAddress, "error", err)
+			continue
+		}
+		sent++
+	}
+	return sent, true
+}
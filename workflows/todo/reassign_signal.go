@@ -0,0 +1,73 @@
+package todo
+
+import (
+	"github.com/nadilas/todo/todopb"
+	"github.com/nadilas/todo/workflows/signalproxy"
+	"go.temporal.io/sdk/workflow"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func (t *Tasks) handleReassignTaskSignal(ctx workflow.Context, sel workflow.Selector) func(c workflow.ReceiveChannel, more bool) {
+	return func(c workflow.ReceiveChannel, more bool) {
+		var r *signalproxy.InputData
+
+		c.Receive(ctx, &r)
+		workflow.GetLogger(ctx).Debug("Received reassign todo signal", "completionId", r.CompletionTargetId)
+
+		if r.CompletionTargetId == "" {
+			workflow.GetLogger(ctx).Warn("Silently ignoring reassign signal with no completionId")
+			return
+		}
+
+		if r.Data == nil {
+			reportSignalError(ctx, r.CompletionTargetId, "reassignment is not defined")
+			return
+		}
+
+		request := &todopb.ReassignTodoRequest{}
+		if err := r.Data.UnmarshalTo(request); err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+
+		if request.Assignee == "" {
+			reportSignalError(ctx, r.CompletionTargetId, "assignee is missing")
+			return
+		}
+
+		idx, err := t.indexOfTask(request.Uuid)
+		if err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+
+		if err := checkRevision(t.Items[idx], request.ExpectedRevision); err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+
+		if err := validateAssignee(ctx, request.Assignee); err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+
+		before := t.auditValues()
+		task := proto.Clone(t.Items[idx]).(*todopb.TodoItem)
+		task.Assignee = request.Assignee
+		// the new assignee starts over, without the reminders sent to the previous one and without escalations
+		task.RemindersSent = 0
+		task.EscalationLevel, task.EscalationsSent = 0, 0
+		task.Revision++
+		t.Items[idx] = task
+		t.audit(ctx, before, ReassignTaskSignal, t.actorOf(request.Owner))
+
+		workflow.GetLogger(ctx).Debug("Reassigned task", "atIndex", idx, "taskId", request.Uuid, "assignee", request.Assignee)
+		resp, _ := anypb.New(&todopb.ReassignTodoResponse{
+			Item:     task,
+			Revision: task.Revision,
+		})
+		reportSignalSuccess(ctx, r.CompletionTargetId, resp)
+		t.refreshReminders(ctx, sel)
+	}
+}
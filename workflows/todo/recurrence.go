@@ -0,0 +1,98 @@
+package todo
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/nadilas/todo/calendar"
+	"github.com/nadilas/todo/todopb"
+	"go.temporal.io/sdk/workflow"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+// validateRecurrence checks the recurrence of the item, which starts from its due date
+func validateRecurrence(item *todopb.TodoItem) error {
+	if item.Recurrence == "" {
+		return nil
+	}
+	if _, err := calendar.ParseRecurrence(item.Recurrence); err != nil {
+		return err
+	}
+	if item.DueAt == nil {
+		return errors.New("invalid recurrence: a recurring todo needs a dueAt")
+	}
+	return nil
+}
+
+// recur adds the next occurrence of every recurring todo completed since open was taken. An occurrence which was
+// completed, reopened and completed again keeps its next occurrence. Returns whether an occurrence was added.
+func (t *Tasks) recur(ctx workflow.Context, open map[string]bool) bool {
+	added := false
+	for _, item := range t.Items {
+		if item.CompletedAt == nil || !open[item.Uuid] || item.Recurrence == "" || item.NextOccurrence != "" {
+			continue
+		}
+		next := nextOccurrence(ctx, item)
+		if next == nil {
+			workflow.GetLogger(ctx).Info("Recurrence ended", "taskId", item.Uuid, "occurrence", item.Occurrence)
+			continue
+		}
+		item.NextOccurrence = next.Uuid
+		item.Revision++
+		t.Items = append(t.Items, next)
+		added = true
+		workflow.GetLogger(ctx).Info("Added next occurrence", "taskId", item.Uuid, "nextTaskId", next.Uuid, "dueAt", next.DueAt.AsTime())
+	}
+	return added
+}
+
+// nextOccurrence returns a new todo for the occurrence following the item, due at the next time of the recurrence
+// after both the due date of the item and now. Missed occurrences are skipped, but count towards the end of the
+// recurrence. Returns nil once the recurrence ended.
+func nextOccurrence(ctx workflow.Context, item *todopb.TodoItem) *todopb.TodoItem {
+	recurrence, err := calendar.ParseRecurrence(item.Recurrence)
+	if err != nil {
+		return nil // rejected when the todo was added or updated
+	}
+	// days are added on the calendar of the reminder's timezone, like recurring reminders
+	loc := time.UTC
+	if item.Reminder != nil {
+		loc = reminderLocation(item.Reminder)
+	}
+	dueAt := item.DueAt.AsTime().In(loc)
+	occurrence := int(item.Occurrence)
+	if occurrence < 1 {
+		occurrence = 1
+	}
+	now := workflow.Now(ctx)
+	nextDueAt, occurrence, ok := recurrence.NextAfter(dueAt, occurrence, now)
+	if !ok {
+		return nil
+	}
+
+	var id string
+	if err := workflow.SideEffect(ctx, func(ctx workflow.Context) interface{} {
+		return uuid.New().String()
+	}).Get(&id); err != nil {
+		return nil
+	}
+	next := proto.Clone(item).(*todopb.TodoItem)
+	next.Uuid = id
+	next.CreatedAt = timestamppb.New(now)
+	next.CompletedAt, next.CompletedBy = nil, ""
+	next.DueAt = timestamppb.New(nextDueAt)
+	next.Occurrence = int32(occurrence)
+	next.NextOccurrence = ""
+	next.Revision = 1
+	// the state kept about the completed occurrence starts over
+	next.RemindersSent, next.SnoozedUntil, next.ReminderHistory = 0, nil, nil
+	next.EscalationLevel, next.EscalationsSent = 0, 0
+	next.DueSoonSent, next.OverdueSent = false, false
+	next.Comments, next.AuditTrail, next.AuditEntriesDropped = nil, nil, 0
+	if next.Reminder.GetAt() != nil {
+		// a reminder at a fixed time keeps its distance to the due date
+		next.Reminder.At = timestamppb.New(next.Reminder.At.AsTime().Add(nextDueAt.Sub(dueAt)))
+	}
+	return next
+}
@@ -1,116 +1,251 @@
 package todo
 
 import (
-	"context"
 	"errors"
+	"fmt"
+	"github.com/nadilas/todo/calendar"
 	"github.com/nadilas/todo/todo"
 	"github.com/nadilas/todo/todopb"
+	"github.com/robfig/cron/v3"
 	"go.temporal.io/sdk/workflow"
 	"time"
 )
 
-func initReminder(ctx workflow.Context, sel workflow.Selector, item *todopb.TodoItem) {
+const day = time.Hour * 24
+
+// validateReminder rejects reminders which could never fire, so they are not silently dropped later on
+func validateReminder(reminder *todopb.Reminder) error {
+	if reminder == nil {
+		return nil
+	}
+	if reminder.Every != "" {
+		every, err := time.ParseDuration(reminder.Every)
+		if err != nil {
+			return fmt.Errorf("invalid reminder every: %w", err)
+		}
+		if every <= 0 {
+			return fmt.Errorf("invalid reminder every: %s is not positive", reminder.Every)
+		}
+	}
+	if reminder.Timezone != "" {
+		if _, err := time.LoadLocation(reminder.Timezone); err != nil {
+			return fmt.Errorf("invalid reminder timezone: %w", err)
+		}
+	}
+	if reminder.Cron != "" {
+		if reminder.Every != "" {
+			return errors.New("invalid reminder: every and cron are exclusive")
+		}
+		if _, err := cron.ParseStandard(reminder.Cron); err != nil {
+			return fmt.Errorf("invalid reminder cron: %w", err)
+		}
+	}
+	return nil
+}
+
+func (t *Tasks) initReminder(ctx workflow.Context, sel workflow.Selector, item *todopb.TodoItem) {
+	if item.EscalationLevel > 0 {
+		t.scheduleEscalation(ctx, sel, item)
+	}
+	if item.SnoozedUntil != nil {
+		t.remindSnoozed(ctx, sel, item)
+		return
+	}
+	if item.Reminder.Cron != "" {
+		t.remindCron(ctx, sel, item)
+		return
+	}
 	if item.Reminder.Every != "" && item.Reminder.At == nil {
-		remindEvery(ctx, sel, item)
+		t.remindEvery(ctx, sel, item)
 	}
 	if item.Reminder.At != nil && item.Reminder.Every == "" {
-		sel.AddFuture(remindAt(ctx, item))
+		t.remindAt(ctx, sel, item, func() {})
 	}
 	if item.Reminder.At != nil && item.Reminder.Every != "" {
-		remindEveryAfter(ctx, sel, item)
+		t.remindEveryAfter(ctx, sel, item)
 	}
 }
 
-// remindEveryAfter sets a timer for
-func remindEveryAfter(ctx workflow.Context, sel workflow.Selector, item *todopb.TodoItem) {
-	// schedule first reminder
-	f, fn := remindAt(ctx, item)
+// remindSnoozed sets a timer for item.SnoozedUntil, which replaces the next reminder. Recurring reminders continue
+// with their cadence from the snoozed reminder on.
+func (t *Tasks) remindSnoozed(ctx workflow.Context, sel workflow.Selector, item *todopb.TodoItem) {
+	now := workflow.Now(ctx)
+	until := item.SnoozedUntil.AsTime()
+	workflow.GetLogger(ctx).Info("reminding at", "at", until, "now", now, "snoozed", true)
 
-	sel.AddFuture(f, func(f workflow.Future) {
-		// execute original function
-		fn(f)
+	timer := workflow.NewTimer(ctx, until.Sub(now))
+	sel.AddFuture(timer, func(f workflow.Future) {
+		t.deliverReminder(ctx, sel, item, func() {
+			item.SnoozedUntil = nil
+			switch {
+			case item.Reminder.Cron != "":
+				t.remindCron(ctx, sel, item)
+			case item.Reminder.Every != "" && item.Reminder.At != nil && item.Reminder.At.AsTime().After(workflow.Now(ctx)):
+				t.remindEveryAfter(ctx, sel, item)
+			case item.Reminder.Every != "":
+				t.remindEvery(ctx, sel, item)
+			}
+		})
+	})
+}
 
-		// start loop
-		remindEvery(ctx, sel, item)
+// remindEveryAfter sets a timer for item.Reminder.At and starts the recurring reminder once it has fired
+func (t *Tasks) remindEveryAfter(ctx workflow.Context, sel workflow.Selector, item *todopb.TodoItem) {
+	t.remindAt(ctx, sel, item, func() {
+		t.remindEvery(ctx, sel, item)
 	})
 }
 
-// remindAt sets a timer for a one-time reminder in the future.
-func remindAt(ctx workflow.Context, item *todopb.TodoItem) (workflow.Future, func(future workflow.Future)) {
+// remindAt sets a timer for a one-time reminder in the future, next is called once it was delivered.
+func (t *Tasks) remindAt(ctx workflow.Context, sel workflow.Selector, item *todopb.TodoItem, next func()) {
 	now := workflow.Now(ctx)
 	timeUntilReminder := item.Reminder.At.AsTime().Sub(now)
 	workflow.GetLogger(ctx).Info("reminding at", "at", item.Reminder.At.AsTime(), "now", now, "in", timeUntilReminder)
 
 	timer := workflow.NewTimer(ctx, timeUntilReminder)
+	sel.AddFuture(timer, func(f workflow.Future) {
+		t.deliverReminder(ctx, sel, item, next)
+	})
+}
 
-	return timer, func(future workflow.Future) {
-		sendReminder(ctx, item)
+// remindCron sets a timer for the next time matching item.Reminder.Cron, which is renewed after every reminder.
+// The next time is computed from workflow.Now, or item.Reminder.At if that is later.
+func (t *Tasks) remindCron(ctx workflow.Context, sel workflow.Selector, item *todopb.TodoItem) {
+	schedule, err := cron.ParseStandard(item.Reminder.Cron)
+	if err != nil {
+		return // rejected when the todo was added or updated
+	}
+	now := workflow.Now(ctx)
+	from := now
+	if item.Reminder.At != nil && item.Reminder.At.AsTime().After(now) {
+		from = item.Reminder.At.AsTime()
 	}
+	next := schedule.Next(from.In(reminderLocation(item.Reminder)))
+	workflow.GetLogger(ctx).Info("reminding at", "at", next, "now", now, "cron", item.Reminder.Cron)
+
+	timer := workflow.NewTimer(ctx, next.Sub(now))
+	sel.AddFuture(timer, func(f workflow.Future) {
+		t.deliverReminder(ctx, sel, item, func() {
+			t.remindCron(ctx, sel, item)
+		})
+	})
 }
 
 // remindEvery start a continuously renewing reminder based on item.Reminder.Every time.Duration
-func remindEvery(ctx workflow.Context, sel workflow.Selector, item *todopb.TodoItem) {
-	duration, err := time.ParseDuration(item.Reminder.Every)
+func (t *Tasks) remindEvery(ctx workflow.Context, sel workflow.Selector, item *todopb.TodoItem) {
+	duration, err := untilNextEvery(workflow.Now(ctx), item.Reminder)
 	if err != nil {
 		return // ignore reminder if incorrect setup
 	}
 
-	t := workflow.NewTimer(ctx, duration)
-	sel.AddFuture(t, reminderLoop(ctx, sel, item))
+	timer := workflow.NewTimer(ctx, duration)
+	sel.AddFuture(timer, func(f workflow.Future) {
+		t.deliverReminder(ctx, sel, item, func() {
+			t.remindEvery(ctx, sel, item)
+		})
+	})
 }
 
-func reminderLoop(ctx workflow.Context, sel workflow.Selector, item *todopb.TodoItem) func(f workflow.Future) {
-	return func(f workflow.Future) {
-		duration, err := time.ParseDuration(item.Reminder.Every)
-		if err != nil {
-			return // ignore reminder if incorrect setup
+// deliverReminder sends the reminder, escalating it if due, and calls next to schedule the following one
+func (t *Tasks) deliverReminder(ctx workflow.Context, sel workflow.Selector, item *todopb.TodoItem, next func()) {
+	t.deliver(ctx, sel, item, func() {
+		if t.Digest != nil {
+			t.collectDigest(ctx, sel, item)
+		} else {
+			t.sendReminder(ctx, item)
 		}
+		t.escalate(ctx, sel, item)
+	}, next)
+}
 
-		workflow.GetLogger(ctx).Info("sending reminder")
-		sendReminder(ctx, item)
+// deliver calls send once the delivery policy of the Owner allows it and next afterwards. A notification due in quiet
+// hours, on a day off or on a holiday is deferred to the next allowed slot. The item is nil for digests.
+func (t *Tasks) deliver(ctx workflow.Context, sel workflow.Selector, item *todopb.TodoItem, send func(), next func()) {
+	if ctx.Err() != nil {
+		return // a cancelled timer must not renew the reminder
+	}
+	policy := t.deliveryPolicy(ctx)
+	if ctx.Err() != nil {
+		return
+	}
 
-		t := workflow.NewTimer(ctx, duration)
-		sel.AddFuture(t, reminderLoop(ctx, sel, item))
+	now := workflow.Now(ctx)
+	allowedAt := policy.NextAllowed(now)
+	if !allowedAt.After(now) {
+		send()
+		next()
+		return
 	}
+
+	workflow.GetLogger(ctx).Info("Deferring notification", "taskId", item.GetUuid(), "until", allowedAt)
+	sel.AddFuture(workflow.NewTimer(ctx, allowedAt.Sub(now)), func(f workflow.Future) {
+		if ctx.Err() != nil {
+			return
+		}
+		send()
+		next()
+	})
 }
 
-func sendReminder(ctx workflow.Context, item *todopb.TodoItem) {
-	if errors.Is(ctx.Err(), context.Canceled) {
-		return
+// deliveryPolicy fetches the delivery policy of the Owner once per run. If fetching fails the policy of the previous
+// run is kept.
+func (t *Tasks) deliveryPolicy(ctx workflow.Context) *calendar.Policy {
+	if t.policyFetched {
+		return t.Policy
+	}
+	t.policyFetched = true
+
+	act := todo.Activities{}
+	aCtx := workflow.WithActivityOptions(ctx, reminderActivityOptions)
+	var policy *calendar.Policy
+	if err := workflow.ExecuteActivity(aCtx, act.FetchDeliveryPolicy, t.Owner).Get(ctx, &policy); err != nil {
+		if ctx.Err() != nil {
+			t.policyFetched = false // the next reminder fetches it again
+			return t.Policy
+		}
+		workflow.GetLogger(ctx).Error("Fetching delivery policy failed", "owner", t.Owner, "error", err)
+		return t.Policy
+	}
+	t.Policy = policy
+	return t.Policy
+}
+
+// untilNextEvery returns the time until the next recurring reminder. Whole days are added on the calendar of the
+// reminder's timezone, so a daily reminder keeps its local time across DST transitions.
+func untilNextEvery(now time.Time, reminder *todopb.Reminder) (time.Duration, error) {
+	every, err := time.ParseDuration(reminder.Every)
+	if err != nil {
+		return 0, err
+	}
+	if reminder.Timezone == "" || every%day != 0 {
+		return every, nil
+	}
+	local := now.In(reminderLocation(reminder))
+	return local.AddDate(0, 0, int(every/day)).Sub(local), nil
+}
+
+// reminderLocation returns the timezone of the reminder, UTC if not set
+func reminderLocation(reminder *todopb.Reminder) *time.Location {
+	loc, err := time.LoadLocation(reminder.Timezone)
+	if err != nil {
+		return time.UTC // rejected when the todo was added or updated
+	}
+	return loc
+}
+
+// sendReminder notifies the assignee of the item with the reminder templates
+func (t *Tasks) sendReminder(ctx workflow.Context, item *todopb.TodoItem) {
+	if ctx.Err() != nil {
+		return // the reminder was cancelled by an update or delete
 	}
 	act := todo.Activities{}
-	aCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
-		ScheduleToCloseTimeout: time.Hour * 2,
-		StartToCloseTimeout:    time.Minute * 1,
+	sent, _ := t.notify(ctx, item, notification{
+		kind: reminderAttempt,
+		prepare: func(aCtx workflow.Context, wfdata *todo.WorkflowData, assignee, recipient *todopb.ADUser) workflow.Future {
+			return workflow.ExecuteActivity(aCtx, act.PrepareReminderModel, wfdata, wfdata.AssignedSince, item.Description, recipient)
+		},
 	})
-	// get user
-	var user *todopb.ADUser
-	workflow.ExecuteActivity(
-		aCtx,
-		act.FetchUser,
-		item.CreatedBy,
-	).Get(ctx, &user)
-	// get workflow data
-	var wfdata *todo.WorkflowData
-	workflow.ExecuteActivity(
-		aCtx,
-		act.CollectWorkflowData,
-		workflow.GetInfo(ctx).WorkflowExecution.ID,
-	).Get(ctx, &wfdata)
-	// get mail data model
-	var mailmodel *todopb.TaskReminderModel
-	workflow.ExecuteActivity(
-		aCtx,
-		act.PrepareReminderModel,
-		wfdata,
-		wfdata.AssignedSince,
-		"Reminder from ToDo: "+item.Description,
-	).Get(ctx, &mailmodel)
-	// send mail
-	workflow.ExecuteActivity(
-		aCtx,
-		act.SendTaskReminder,
-		mailmodel,
-		[]string{user.EmailAddress},
-	).Get(ctx, &user)
+	if sent > 0 {
+		item.RemindersSent++
+	}
 }
@@ -0,0 +1,90 @@
+package todo
+
+import (
+	"errors"
+	"fmt"
+	"github.com/nadilas/todo/todopb"
+	"github.com/nadilas/todo/workflows/signalproxy"
+	"go.temporal.io/sdk/workflow"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+func (t *Tasks) handleSnoozeTaskSignal(ctx workflow.Context, sel workflow.Selector) func(c workflow.ReceiveChannel, more bool) {
+	return func(c workflow.ReceiveChannel, more bool) {
+		var r *signalproxy.InputData
+
+		c.Receive(ctx, &r)
+		workflow.GetLogger(ctx).Debug("Received snooze todo signal", "completionId", r.CompletionTargetId)
+
+		if r.CompletionTargetId == "" {
+			workflow.GetLogger(ctx).Warn("Silently ignoring snooze signal with no completionId")
+			return
+		}
+
+		if r.Data == nil {
+			reportSignalError(ctx, r.CompletionTargetId, "snooze is not defined")
+			return
+		}
+
+		request := &todopb.SnoozeTodoRequest{}
+		if err := r.Data.UnmarshalTo(request); err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+
+		until, err := snoozedUntil(workflow.Now(ctx), request)
+		if err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+
+		idx, err := t.indexOfTask(request.Uuid)
+		if err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+
+		task := t.Items[idx]
+		if task.Reminder == nil {
+			reportSignalError(ctx, r.CompletionTargetId, "todo has no reminder to snooze")
+			return
+		}
+		before := t.auditValues()
+		task.SnoozedUntil = timestamppb.New(until)
+		task.Revision++
+		t.audit(ctx, before, SnoozeTaskSignal, t.actorOf(request.Owner))
+
+		workflow.GetLogger(ctx).Debug("Snoozed task", "atIndex", idx, "taskId", request.Uuid, "until", until)
+		resp, _ := anypb.New(&todopb.SnoozeTodoResponse{
+			Item: task,
+		})
+		reportSignalSuccess(ctx, r.CompletionTargetId, resp)
+		t.refreshReminder(ctx, sel, task)
+	}
+}
+
+// snoozedUntil returns the time the next reminder is pushed to by the request
+func snoozedUntil(now time.Time, request *todopb.SnoozeTodoRequest) (time.Time, error) {
+	switch {
+	case request.Duration != "" && request.Until != nil:
+		return time.Time{}, errors.New("invalid snooze: duration and until are exclusive")
+	case request.Duration != "":
+		duration, err := time.ParseDuration(request.Duration)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid snooze duration: %w", err)
+		}
+		if duration <= 0 {
+			return time.Time{}, fmt.Errorf("invalid snooze duration: %s is not positive", request.Duration)
+		}
+		return now.Add(duration), nil
+	case request.Until != nil:
+		if !request.Until.AsTime().After(now) {
+			return time.Time{}, fmt.Errorf("invalid snooze until: %s is not in the future", request.Until.AsTime().Format(time.RFC3339))
+		}
+		return request.Until.AsTime(), nil
+	default:
+		return time.Time{}, errors.New("invalid snooze: duration or until is required")
+	}
+}
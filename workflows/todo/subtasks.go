@@ -0,0 +1,112 @@
+package todo
+
+import (
+	"errors"
+	"github.com/nadilas/todo/todopb"
+	"go.temporal.io/sdk/workflow"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Subtasks are kept in Items like every other todo and point to their parent with the parentUuid, so reminders,
+// filters and the pending count apply to them as well. Only the tree query nests them under their parents.
+
+// validateSubtask checks the parent of a todo being added exists
+func (t *Tasks) validateSubtask(item *todopb.TodoItem) error {
+	if len(item.Subtasks) > 0 {
+		return errors.New("subtasks are added on their own with the parentUuid of their parent")
+	}
+	if item.ParentUuid == "" {
+		return nil
+	}
+	if item.ParentUuid == item.Uuid {
+		return errors.New("a todo can't be its own parent")
+	}
+	// the uuid of an added todo is new and the parentUuid is immutable, so no parent can be one of its subtasks
+	_, err := t.indexOfTask(item.ParentUuid)
+	return err
+}
+
+// parentOf returns the parent of the item, nil for top level todos
+func (t *Tasks) parentOf(item *todopb.TodoItem) *todopb.TodoItem {
+	if item.ParentUuid == "" {
+		return nil
+	}
+	idx, err := t.indexOfTask(item.ParentUuid)
+	if err != nil {
+		return nil
+	}
+	return t.Items[idx]
+}
+
+// subtasksCompleted tells whether every subtask of the todo is completed, and whether it has subtasks at all
+func (t *Tasks) subtasksCompleted(uuid string) (completed bool, found bool) {
+	completed = true
+	for _, item := range t.Items {
+		if item.ParentUuid == uuid {
+			found = true
+			completed = completed && item.CompletedAt != nil
+		}
+	}
+	return completed, found
+}
+
+// withDescendants returns the uuid of the todo followed by the ones of its subtasks, their subtasks and so on. Each
+// uuid is returned once, even if the parents of the todos form a cycle.
+func (t *Tasks) withDescendants(uuid string) []string {
+	uuids := []string{uuid}
+	visited := map[string]bool{uuid: true}
+	for i := 0; i < len(uuids); i++ {
+		for _, item := range t.Items {
+			if item.ParentUuid == uuids[i] && !visited[item.Uuid] {
+				visited[item.Uuid] = true
+				uuids = append(uuids, item.Uuid)
+			}
+		}
+	}
+	return uuids
+}
+
+// completeParents completes the parents of the item which are completed with their subtasks once every one of their
+// subtasks is completed, and reopens them once one is not. Returns whether a parent changed.
+func (t *Tasks) completeParents(ctx workflow.Context, item *todopb.TodoItem) bool {
+	changed := false
+	visited := map[string]bool{item.Uuid: true}
+	for parent := t.parentOf(item); parent != nil && parent.CompleteWithSubtasks && !visited[parent.Uuid]; parent = t.parentOf(parent) {
+		visited[parent.Uuid] = true
+		completed, found := t.subtasksCompleted(parent.Uuid)
+		if !found || completed == (parent.CompletedAt != nil) {
+			break
+		}
+		if completed {
+			parent.CompletedAt = timestamppb.New(workflow.Now(ctx))
+			parent.CompletedBy = item.CompletedBy
+		} else {
+			parent.CompletedAt, parent.CompletedBy = nil, ""
+		}
+		parent.Revision++
+		changed = true
+		workflow.GetLogger(ctx).Debug("Completion of subtasks changed parent", "taskId", parent.Uuid, "completed", completed)
+		item = parent
+	}
+	return changed
+}
+
+// taskTree returns the top level todos of the items with their subtasks nested, the items are not modified
+func taskTree(items []*todopb.TodoItem) []*todopb.TodoItem {
+	nodes := make(map[string]*todopb.TodoItem, len(items))
+	for _, item := range items {
+		node := proto.Clone(item).(*todopb.TodoItem)
+		node.Subtasks = nil
+		nodes[item.Uuid] = node
+	}
+	roots := make([]*todopb.TodoItem, 0)
+	for _, item := range items {
+		if parent, ok := nodes[item.ParentUuid]; ok && item.ParentUuid != "" {
+			parent.Subtasks = append(parent.Subtasks, nodes[item.Uuid])
+		} else {
+			roots = append(roots, nodes[item.Uuid])
+		}
+	}
+	return roots
+}
@@ -2,13 +2,30 @@ package todo
 
 import (
 	"fmt"
+	"github.com/nadilas/todo/calendar"
+	"github.com/nadilas/todo/todo"
 	"github.com/nadilas/todo/todopb"
 	"go.temporal.io/sdk/workflow"
+	"time"
 )
 
 type Tasks struct {
-	Items     []*todopb.TodoItem
-	reminders []reminder
+	// Owner is the user owning the Tasklist, whose delivery policy applies to the reminders
+	Owner string
+	Items []*todopb.TodoItem
+	// Policy is the delivery policy last fetched for the Owner, kept if a later run fails to fetch it
+	Policy        *calendar.Policy
+	policyFetched bool
+	// Digest merges the reminders due within its window into one email, every reminder is sent on its own if nil. The
+	// Tasklist keeps running while it is set, carrying it into new runs.
+	Digest *todopb.Digest
+	// DigestItems are the uuids of the todos due in the open digest window, which closes at DigestDueAt
+	DigestItems []string
+	DigestDueAt time.Time
+	// DueSoonBefore is how long before its due date a todo is notified as due soon, read from the configuration once
+	// and carried into new runs, so a changed configuration can't alter the timers of a replayed history
+	DueSoonBefore time.Duration
+	reminders     []reminder
 }
 
 type reminder struct {
@@ -21,10 +38,34 @@ type signalSetup struct {
 	fn      func(c workflow.ReceiveChannel, more bool)
 }
 
+func (t *Tasks) signals(ctx workflow.Context, sel workflow.Selector) []signalSetup {
+	return []signalSetup{
+		{channel: workflow.GetSignalChannel(ctx, AddTaskSignal), fn: t.handleAddTaskSignal(ctx, sel)},
+		{channel: workflow.GetSignalChannel(ctx, UpdateTaskSignal), fn: t.handleUpdateTaskSignal(ctx, sel)},
+		{channel: workflow.GetSignalChannel(ctx, DeleteTaskSignal), fn: t.handleDeleteTaskSignal(ctx, sel)},
+		{channel: workflow.GetSignalChannel(ctx, SnoozeTaskSignal), fn: t.handleSnoozeTaskSignal(ctx, sel)},
+		{channel: workflow.GetSignalChannel(ctx, ReassignTaskSignal), fn: t.handleReassignTaskSignal(ctx, sel)},
+		{channel: workflow.GetSignalChannel(ctx, AddCommentSignal), fn: t.handleAddCommentSignal(ctx, sel)},
+		{channel: workflow.GetSignalChannel(ctx, SetDigestSignal), fn: t.handleSetDigestSignal(ctx, sel)},
+	}
+}
+
 func (t *Tasks) setupSignals(ctx workflow.Context, sel workflow.Selector) {
-	sel.AddReceive(workflow.GetSignalChannel(ctx, AddTaskSignal), t.handleAddTaskSignal(ctx, sel))
-	sel.AddReceive(workflow.GetSignalChannel(ctx, UpdateTaskSignal), t.handleUpdateTaskSignal(ctx, sel))
-	sel.AddReceive(workflow.GetSignalChannel(ctx, DeleteTaskSignal), t.handleDeleteTaskSignal(ctx, sel))
+	for _, signal := range t.signals(ctx, sel) {
+		sel.AddReceive(signal.channel, signal.fn)
+	}
+}
+
+// drainSignals handles every signal which was received but not processed yet.
+// Signals left in the channels are lost once the run completes or continues as new.
+func (t *Tasks) drainSignals(ctx workflow.Context, sel workflow.Selector) {
+	drain := workflow.NewSelector(ctx)
+	for _, signal := range t.signals(ctx, sel) {
+		drain.AddReceive(signal.channel, signal.fn)
+	}
+	for drain.HasPending() {
+		drain.Select(ctx)
+	}
 }
 
 func (t *Tasks) queryPendingTasks() ([]*todopb.TodoItem, error) {
@@ -41,11 +82,43 @@ func (t *Tasks) queryAllTasks() ([]*todopb.TodoItem, error) {
 	return t.Items, nil
 }
 
+// queryOverdueTasks returns the pending todos whose due date is not after now
+func (t *Tasks) queryOverdueTasks(now time.Time) ([]*todopb.TodoItem, error) {
+	i := make([]*todopb.TodoItem, 0)
+	for _, item := range t.Items {
+		if item.CompletedAt == nil && item.DueAt != nil && !item.DueAt.AsTime().After(now) {
+			i = append(i, item)
+		}
+	}
+	return i, nil
+}
+
+// stats counts the todos of the Tasklist
+func (t *Tasks) stats() todo.TasklistStats {
+	var stats todo.TasklistStats
+	for _, item := range t.Items {
+		if item.CompletedAt == nil {
+			stats.Pending++
+		} else {
+			stats.Completed++
+		}
+	}
+	return stats
+}
+
+// pendingTasksCount counts the todos which are not completed, open subtasks keep the Tasklist running even if their
+// parent is completed
 func (t *Tasks) pendingTasksCount() int {
 	pending, _ := t.queryPendingTasks()
 	return len(pending)
 }
 
+// keepsRunning reports whether the Tasklist has to continue, because todos are pending or it holds the digest
+// preference of the owner, which would be lost with the closed run
+func (t *Tasks) keepsRunning() bool {
+	return t.pendingTasksCount() > 0 || t.Digest != nil
+}
+
 func (t *Tasks) indexOfTask(uuid string) (int, error) {
 	for i, task := range t.Items {
 		if task.Uuid == uuid {
@@ -55,6 +128,14 @@ func (t *Tasks) indexOfTask(uuid string) (int, error) {
 	return -1, fmt.Errorf("task not found: %s", uuid)
 }
 
+// checkRevision rejects a change expecting the todo at another revision, an expected revision of 0 accepts any
+func checkRevision(item *todopb.TodoItem, expected int64) error {
+	if expected != 0 && item.Revision != expected {
+		return fmt.Errorf("revision conflict: todo %s is at revision %d, not %d", item.Uuid, item.Revision, expected)
+	}
+	return nil
+}
+
 func (t *Tasks) indexOfReminder(uuid string) (int, error) {
 	for i, reminder := range t.reminders {
 		if reminder.taskId == uuid {
@@ -66,25 +147,28 @@ func (t *Tasks) indexOfReminder(uuid string) (int, error) {
 
 func (t *Tasks) refreshReminders(ctx workflow.Context, sel workflow.Selector) {
 	for _, item := range t.Items {
-		// if we need a reminder start timer
-		if item.Reminder != nil {
-			idx, _ := t.indexOfReminder(item.Uuid)
-			if idx >= 0 {
-				// cancel previous reminder before setting up new one
-				workflow.GetLogger(ctx).Info("Cancelling reminder context", "taskId", item.Uuid)
-				rem := t.reminders[idx]
-				rem.cancelFn()
-				t.reminders = append(t.reminders[0:idx], t.reminders[idx+1:]...)
-			}
-
-			// setup new reminder
-			timerCtx, cancel := workflow.WithCancel(ctx)
-			t.reminders = append(t.reminders, reminder{
-				taskId:   item.Uuid,
-				cancelFn: cancel,
-			})
-			workflow.GetLogger(ctx).Info("Setup new reminder context", "taskId", item.Uuid)
-			initReminder(timerCtx, sel, item)
+		t.refreshReminder(ctx, sel, item)
+	}
+}
+
+func (t *Tasks) refreshReminder(ctx workflow.Context, sel workflow.Selector, item *todopb.TodoItem) {
+	// cancel previous reminder before setting up new one
+	t.cancelReminder(ctx, item.Uuid)
+
+	// if we need a reminder or due date start timer, completed todos are not reminded about and blocked todos only
+	// once their blockers are completed
+	if (item.Reminder != nil || item.DueAt != nil) && item.CompletedAt == nil {
+		timerCtx, cancel := workflow.WithCancel(ctx)
+		t.reminders = append(t.reminders, reminder{
+			taskId:   item.Uuid,
+			cancelFn: cancel,
+		})
+		workflow.GetLogger(ctx).Info("Setup new reminder context", "taskId", item.Uuid)
+		if item.Reminder != nil && !t.blocked(item) {
+			t.initReminder(timerCtx, sel, item)
+		}
+		if item.DueAt != nil {
+			t.initDue(timerCtx, sel, item)
 		}
 	}
 }
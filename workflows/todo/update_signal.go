@@ -1,10 +1,16 @@
 package todo
 
 import (
+	"errors"
+	"fmt"
 	"github.com/nadilas/todo/todopb"
 	"github.com/nadilas/todo/workflows/signalproxy"
 	"go.temporal.io/sdk/workflow"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"strings"
 )
 
 func (t *Tasks) handleUpdateTaskSignal(ctx workflow.Context, sel workflow.Selector) func(c workflow.ReceiveChannel, more bool) {
@@ -41,19 +47,154 @@ func (t *Tasks) handleUpdateTaskSignal(ctx workflow.Context, sel workflow.Select
 			return
 		}
 
-		// update elements task entirely
-		task := t.Items[idx]
+		if err := checkRevision(t.Items[idx], request.ExpectedRevision); err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
 
-		task.Description = request.Item.Description
-		task.CompletedAt = request.Item.CompletedAt
-		task.CompletedBy = request.Item.CompletedBy
-		task.Reminder = request.Item.Reminder
+		// replace the fields given by the owner, keeping the state of the Tasklist
+		task, err := applyUpdate(t.Items[idx], request.Item, request.UpdateMask)
+		if err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+
+		if err := validateReminder(task.Reminder); err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+
+		if err := validateEscalation(task); err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+
+		if err := t.validateBlockers(task); err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+
+		if err := validateRecurrence(task); err != nil {
+			reportSignalError(ctx, r.CompletionTargetId, err.Error())
+			return
+		}
+		before := t.auditValues()
+		open := t.openTasks()
+		task.Revision++
+		t.Items[idx] = task
+		t.completeParents(ctx, task)
+		t.recur(ctx, open)
+		t.audit(ctx, before, UpdateTaskSignal, t.actorOf(request.Owner))
 
 		workflow.GetLogger(ctx).Debug("Updated task", "atIndex", idx, "taskId", request.Item.Uuid)
 		resp, _ := anypb.New(&todopb.UpdateTodoResponse{
-			Item: task,
+			Item:     task,
+			Revision: task.Revision,
 		})
 		reportSignalSuccess(ctx, r.CompletionTargetId, resp)
 		t.refreshReminders(ctx, sel)
+		t.notifyUnblocked(ctx, sel, open)
+	}
+}
+
+// updatableFields are the fields of a todo set by its owner, which are updated if the update mask is empty
+var updatableFields = []string{
+	"description",
+	"reminder",
+	"completedAt",
+	"completedBy",
+	"escalation",
+	"dueAt",
+	"priority",
+	"tags",
+	"labels",
+	"completeWithSubtasks",
+	"blockedBy",
+	"recurrence",
+	"watchers",
+}
+
+// immutableFields identify a todo and its place in the tree of subtasks, they can't be updated
+var immutableFields = []string{"uuid", "createdBy", "createdAt", "parentUuid"}
+
+// applyUpdate returns the todo with the fields in the mask taken from the update, or every updatable field if the mask
+// is empty. The state the Tasklist keeps about the todo is carried over, unless the update changes what the state is
+// about.
+func applyUpdate(task, update *todopb.TodoItem, mask *fieldmaskpb.FieldMask) (*todopb.TodoItem, error) {
+	paths := mask.GetPaths()
+	if len(paths) == 0 {
+		paths = updatableFields
+	}
+	updated := proto.Clone(task).(*todopb.TodoItem)
+	for _, path := range paths {
+		fields := strings.Split(path, ".")
+		err := updatable(fields[0])
+		if err == nil {
+			err = updatePath(updated.ProtoReflect(), update.ProtoReflect(), fields)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid update mask: %s: %w", path, err)
+		}
+	}
+
+	if !proto.Equal(task.Reminder, updated.Reminder) {
+		// a new schedule replaces the snoozed reminder
+		updated.SnoozedUntil = nil
+	}
+	if !proto.Equal(task.Escalation, updated.Escalation) {
+		// a new escalation starts over
+		updated.EscalationLevel, updated.EscalationsSent = 0, 0
+	}
+	if !proto.Equal(task.DueAt, updated.DueAt) {
+		// a new due date is notified again
+		updated.DueSoonSent, updated.OverdueSent = false, false
+	}
+	return updated, nil
+}
+
+// updatable tells why a field of a todo can't be updated
+func updatable(name string) error {
+	switch {
+	case contains(updatableFields, name):
+		return nil
+	case contains(immutableFields, name):
+		return errors.New("the field is immutable")
+	case name == "assignee":
+		return errors.New("the field is changed by reassigning the todo")
+	case (&todopb.TodoItem{}).ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(name)) != nil:
+		return errors.New("the field is kept by the Tasklist")
+	default:
+		return errors.New("unknown field")
+	}
+}
+
+// updatePath sets the field at the path of dst to the one of src, clearing it if it is not set in src. Fields of
+// messages like reminder.every can be updated on their own.
+func updatePath(dst, src protoreflect.Message, path []string) error {
+	name := path[0]
+	fd := dst.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if fd == nil {
+		return errors.New("unknown field")
+	}
+	if len(path) == 1 {
+		if src.Has(fd) {
+			dst.Set(fd, src.Get(fd))
+		} else {
+			dst.Clear(fd)
+		}
+		return nil
+	}
+	if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+		return fmt.Errorf("%s has no fields", name)
+	}
+	return updatePath(dst.Mutable(fd).Message(), src.Get(fd).Message(), path[1:])
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
 	}
+	return false
 }
@@ -1,6 +1,7 @@
 package todo
 
 import (
+	"github.com/nadilas/todo/todopb"
 	"go.temporal.io/sdk/workflow"
 	"time"
 )
@@ -10,19 +11,38 @@ const MaxHoursPerRun = 168
 const MaxExecutionDurationPerRun = time.Hour * MaxHoursPerRun
 
 const (
-	AddTaskSignal     = "add_task"
-	DeleteTaskSignal  = "delete_task"
-	UpdateTaskSignal  = "update_task"
-	PendingTasksQuery = "pending_tasks"
-	AllTasksQuery     = "all_tasks"
+	AddTaskSignal      = "add_task"
+	DeleteTaskSignal   = "delete_task"
+	UpdateTaskSignal   = "update_task"
+	SnoozeTaskSignal   = "snooze_task"
+	ReassignTaskSignal = "reassign_task"
+	AddCommentSignal   = "add_comment"
+	SetDigestSignal    = "set_digest"
+	PendingTasksQuery  = "pending_tasks"
+	BlockedTasksQuery  = "blocked_tasks"
+	AllTasksQuery      = "all_tasks"
+	OverdueTasksQuery  = "overdue_tasks"
+	// FilteredTasksQuery takes a *todopb.TodoFilter
+	FilteredTasksQuery = "filtered_tasks"
+	// TaskTreeQuery returns the top level todos with their subtasks nested
+	TaskTreeQuery = "task_tree"
+	// TaskHistoryQuery takes the uuid of a todo and returns its *todopb.TodoHistory
+	TaskHistoryQuery = "task_history"
 )
 
+// TasklistWorkflowId returns the workflow id of the Tasklist owned by the given user
+func TasklistWorkflowId(owner string) string {
+	return "tasklist-" + owner
+}
+
 func Tasklist(ctx workflow.Context, tasks *Tasks) (*Tasks, error) {
 	logger := workflow.GetLogger(ctx)
 	eventLoop := 0
 	sel := workflow.NewSelector(ctx)
 	codeRefreshTriggered := false
+	tasks.initDueSoonBefore(ctx)
 	tasks.refreshReminders(ctx, sel)
+	tasks.refreshDigest(ctx, sel)
 
 	// code refresh for all workflows
 	sel.AddFuture(workflow.NewTimer(ctx, MaxExecutionDurationPerRun), func(f workflow.Future) {
@@ -32,24 +52,64 @@ func Tasklist(ctx workflow.Context, tasks *Tasks) (*Tasks, error) {
 
 	tasks.setupSignals(ctx, sel)
 
-	if err := workflow.SetQueryHandler(ctx, PendingTasksQuery, tasks.queryPendingTasks); err != nil {
+	if err := workflow.SetQueryHandler(ctx, PendingTasksQuery, withDue(ctx, tasks.queryPendingTasks)); err != nil {
+		return tasks, err
+	}
+
+	if err := workflow.SetQueryHandler(ctx, BlockedTasksQuery, withDue(ctx, tasks.queryBlockedTasks)); err != nil {
 		return tasks, err
 	}
 
-	if err := workflow.SetQueryHandler(ctx, AllTasksQuery, tasks.queryAllTasks); err != nil {
+	if err := workflow.SetQueryHandler(ctx, AllTasksQuery, withDue(ctx, tasks.queryAllTasks)); err != nil {
+		return tasks, err
+	}
+
+	if err := workflow.SetQueryHandler(ctx, OverdueTasksQuery, withDue(ctx, func() ([]*todopb.TodoItem, error) {
+		return tasks.queryOverdueTasks(workflow.Now(ctx))
+	})); err != nil {
+		return tasks, err
+	}
+
+	if err := workflow.SetQueryHandler(ctx, FilteredTasksQuery, func(filter *todopb.TodoFilter) ([]*todopb.TodoItem, error) {
+		return withDue(ctx, func() ([]*todopb.TodoItem, error) {
+			return tasks.queryFilteredTasks(filter)
+		})()
+	}); err != nil {
+		return tasks, err
+	}
+
+	if err := workflow.SetQueryHandler(ctx, TaskTreeQuery, func() ([]*todopb.TodoItem, error) {
+		items, err := withDue(ctx, tasks.queryAllTasks)()
+		if err != nil {
+			return nil, err
+		}
+		return taskTree(items), nil
+	}); err != nil {
+		return tasks, err
+	}
+
+	if err := workflow.SetQueryHandler(ctx, TaskHistoryQuery, tasks.queryTaskHistory); err != nil {
 		return tasks, err
 	}
 
 	for {
 		sel.Select(ctx)
 		eventLoop++
-		if tasks.pendingTasksCount() < 1 || eventLoop >= maxEventsPerRun || codeRefreshTriggered {
+		if eventLoop >= maxEventsPerRun || codeRefreshTriggered {
 			break
 		}
+		if !tasks.keepsRunning() {
+			// a todo may have been added while the last one was completed
+			tasks.drainSignals(ctx, sel)
+			if !tasks.keepsRunning() {
+				break
+			}
+		}
 	}
+	tasks.drainSignals(ctx, sel)
 
 	notFinishedButLongHistory := eventLoop >= maxEventsPerRun
-	if tasks.pendingTasksCount() > 0 && (notFinishedButLongHistory || codeRefreshTriggered) {
+	if tasks.keepsRunning() && (notFinishedButLongHistory || codeRefreshTriggered) {
 		logger.Debug("Clearing workflow history and carrying state to continue as new instance")
 		return nil, workflow.NewContinueAsNewError(ctx, Tasklist, tasks)
 	}
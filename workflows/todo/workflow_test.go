@@ -1,15 +1,23 @@
 package todo_test
 
 import (
+	"context"
+	"fmt"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/kr/pretty"
+	"github.com/nadilas/todo/calendar"
 	todo2 "github.com/nadilas/todo/todo"
 	"github.com/nadilas/todo/todopb"
 	. "github.com/nadilas/todo/workflows/testutils"
 	"github.com/nadilas/todo/workflows/todo"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/temporal"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"testing"
 	"time"
@@ -22,6 +30,20 @@ var dummyUser = &todopb.ADUser{
 	SamAccountName: "user",
 }
 
+var (
+	berlin, _  = time.LoadLocation("Europe/Berlin")
+	newYork, _ = time.LoadLocation("America/New_York")
+	// summer time starts on the 28th of March 2021 in Berlin
+	berlinBeforeDst = time.Date(2021, 3, 27, 12, 0, 0, 0, berlin)
+	// summer time ends on the 7th of November 2021 in New York
+	newYorkBeforeDst = time.Date(2021, 11, 5, 12, 0, 0, 0, newYork)
+	berlinSaturday   = time.Date(2021, 8, 7, 10, 0, 0, 0, berlin)
+)
+
+var dummyReminderModel = &todopb.TaskReminderModel{
+	Subject: "Reminder from ToDo: some task",
+}
+
 type TasklistTestSuite struct {
 	BDTemporalTestSuite
 }
@@ -112,6 +134,60 @@ func (s *TasklistTestSuite) Test_Tasks_Queries() {
 		s.When(startAWorkflow(&tasks)),
 		s.Then(WorkflowFinished()),
 	)
+	s.Scenario("filtering by priority, tags and labels",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, &todopb.TodoItem{
+			Uuid:     "t1",
+			Priority: todopb.TodoItem_HIGH,
+			Tags:     []string{"billing"},
+			Labels:   map[string]string{"team": "payments"},
+		}, &todopb.TodoItem{
+			Uuid:     "t2",
+			Priority: todopb.TodoItem_LOW,
+			Tags:     []string{"billing", "q3"},
+			Labels:   map[string]string{"team": "search"},
+		}, &todopb.TodoItem{
+			Uuid:        "t3",
+			Priority:    todopb.TodoItem_HIGH,
+			Labels:      map[string]string{"team": "payments"},
+			CompletedAt: timestamppb.Now(),
+		})),
+		s.And(filteredTasksIn(time.Minute, &todopb.TodoFilter{
+			Priorities: []todopb.TodoItem_Priority{todopb.TodoItem_HIGH, todopb.TodoItem_URGENT},
+		}, "t1")),
+		s.And(filteredTasksIn(time.Minute, &todopb.TodoFilter{
+			Tags: []string{"billing", "q3"},
+		}, "t2")),
+		s.And(filteredTasksIn(time.Minute, &todopb.TodoFilter{
+			LabelSelector: "team!=search",
+		}, "t1")),
+		s.And(filteredTasksIn(time.Minute, &todopb.TodoFilter{
+			LabelSelector:    "team=payments",
+			IncludeCompleted: true,
+		}, "t1", "t3")),
+		s.And(filteredTasksIn(time.Minute, &todopb.TodoFilter{
+			LabelSelector: "!team",
+		})),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("filtering with an invalid label selector fails",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, dummyTask)),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			st.Env.RegisterDelayedCallback(func() {
+				_, err := st.Env.QueryWorkflow(todo.FilteredTasksQuery, &todopb.TodoFilter{
+					LabelSelector: "team=payments,=search",
+				})
+				if st.Error(err) {
+					st.Contains(err.Error(), "invalid label selector")
+				}
+			}, time.Minute)
+		}),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
 }
 
 func (s *TasklistTestSuite) Test_AddSignal() {
@@ -123,6 +199,20 @@ func (s *TasklistTestSuite) Test_AddSignal() {
 		CreatedBy:   "user1",
 		CreatedAt:   timestamppb.Now(),
 	}
+	dummyCompletedTask := &todopb.TodoItem{
+		Uuid:        "t1",
+		Description: "some task",
+		CreatedBy:   "user1",
+		CreatedAt:   dummyTask.CreatedAt,
+		CompletedAt: timestamppb.Now(),
+		CompletedBy: "user1",
+	}
+	dummyTask2 := &todopb.TodoItem{
+		Uuid:        "t2",
+		Description: "some task 2",
+		CreatedBy:   "user1",
+		CreatedAt:   timestamppb.Now(),
+	}
 	s.Scenario("adding one todo is persisted",
 		s.setupMocks,
 		s.Given(aTasklist(&tasks)),
@@ -133,6 +223,19 @@ func (s *TasklistTestSuite) Test_AddSignal() {
 		s.When(startAWorkflow(&tasks)),
 		s.Then(WorkflowContinuedAsNew()),
 	)
+	s.Scenario("adding a todo while completing the last one keeps the tasklist running",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, dummyTask)),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item: dummyCompletedTask,
+		}), nil)),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.AddTaskSignal, MustMarshalAny(&todopb.AddTodoRequest{
+			Item: dummyTask2,
+		}), nil)),
+		s.And(queryTasksIn(time.Minute*2, todo.PendingTasksQuery, dummyTask2)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
 	s.Scenario("adding a non-todo fails",
 		s.setupMocks,
 		s.Given(aTasklist(&tasks)),
@@ -156,6 +259,22 @@ func (s *TasklistTestSuite) Test_AddSignal() {
 		s.When(startAWorkflow(&tasks)),
 		s.Then(WorkflowContinuedAsNew()),
 	)
+	s.Scenario("adding a todo with an invalid cron fails",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, dummyTask)),
+		s.And(ProxySignalErrored(time.Minute*1, todo.AddTaskSignal, MustMarshalAny(&todopb.AddTodoRequest{
+			Item: &todopb.TodoItem{
+				Uuid:        "t2",
+				Description: "some task 2",
+				Reminder: &todopb.Reminder{
+					Cron: "0 9 * *",
+				},
+			},
+		}), "invalid reminder cron")),
+		s.And(queryTasksIn(time.Minute*2, todo.AllTasksQuery, dummyTask)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
 }
 
 func (s *TasklistTestSuite) Test_DeleteTaskSignal() {
@@ -306,6 +425,41 @@ func (s *TasklistTestSuite) Test_UpdateTaskSignal() {
 		s.When(startAWorkflow(&tasks)),
 		s.Then(WorkflowContinuedAsNew()),
 	)
+	s.Scenario("updating a todo applies its priority, tags and labels and keeps its state",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, &todopb.TodoItem{
+			Uuid:          "t1",
+			Description:   "some task",
+			CreatedBy:     dummyUser.SamAccountName,
+			CreatedAt:     dummyTask.CreatedAt,
+			RemindersSent: 2,
+		})),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item: &todopb.TodoItem{
+				Uuid:        "t1",
+				Description: "some task",
+				Priority:    todopb.TodoItem_URGENT,
+				Tags:        []string{"billing"},
+				Labels:      map[string]string{"team": "payments"},
+			},
+		}), nil)),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			st.Env.RegisterDelayedCallback(func() {
+				value, err := st.Env.QueryWorkflow(todo.AllTasksQuery)
+				st.NoError(err)
+				var items []*todopb.TodoItem
+				st.NoError(value.Get(&items))
+				st.Equal(todopb.TodoItem_URGENT, items[0].Priority)
+				st.Equal([]string{"billing"}, items[0].Tags)
+				st.Equal(map[string]string{"team": "payments"}, items[0].Labels)
+				st.Equal(int32(2), items[0].RemindersSent)
+				st.Equal(dummyUser.SamAccountName, items[0].CreatedBy)
+			}, time.Minute*2)
+		}),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
 	s.Scenario("completing the last open task, finishes workflow",
 		s.setupMocks,
 		s.Given(aTasklist(&tasks, dummyTask, dummyCompletedTask)),
@@ -317,122 +471,2064 @@ func (s *TasklistTestSuite) Test_UpdateTaskSignal() {
 		s.When(startAWorkflow(&tasks)),
 		s.Then(WorkflowFinished()),
 	)
+	s.Scenario("updating a todo with an invalid every fails",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, dummyTask)),
+		s.And(ProxySignalErrored(time.Minute*1, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item: &todopb.TodoItem{
+				Uuid:        dummyTask.Uuid,
+				Description: dummyTask.Description,
+				Reminder: &todopb.Reminder{
+					Every: "daily",
+				},
+			},
+		}), "invalid reminder every")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
 }
 
-func (s *TasklistTestSuite) Test_Reminders() {
+func (s *TasklistTestSuite) Test_Revisions() {
 	var tasks *todo.Tasks
-	oneDayHours := 24
-	dummyTask := &todopb.TodoItem{
-		Uuid:        "t1",
-		Description: "some task",
-		CreatedBy:   dummyUser.SamAccountName,
-		CreatedAt:   timestamppb.Now(),
-		Reminder: &todopb.Reminder{
-			Every: (time.Hour * time.Duration(oneDayHours)).String(),
-		},
-	}
-	dummyTaskWith1Reminder := &todopb.TodoItem{
-		Uuid:        "t1",
-		Description: "some task",
-		CreatedBy:   dummyUser.SamAccountName,
-		CreatedAt:   timestamppb.Now(),
-		Reminder: &todopb.Reminder{
-			At: timestamppb.New(time.Now().Add(time.Hour * 48)),
-		},
-	}
-	afterDays := 4
-	dummyTaskWithDailyReminderAfter := &todopb.TodoItem{
-		Uuid:        "t1",
-		Description: "some task",
-		CreatedBy:   dummyUser.SamAccountName,
-		CreatedAt:   timestamppb.Now(),
-		Reminder: &todopb.Reminder{
-			At:    timestamppb.New(time.Now().Add(time.Hour * time.Duration(oneDayHours) * time.Duration(afterDays))),
-			Every: (time.Hour * time.Duration(oneDayHours)).String(),
-		},
+	revisedTask := func() *todopb.TodoItem {
+		return &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.Now(),
+			Revision:    3,
+		}
 	}
-	everyDayReminderCount := todo.MaxHoursPerRun/oneDayHours - 1
-	s.Scenario("reminder every day",
+	s.Scenario("adding a todo starts at the first revision",
 		s.setupMocks,
-		s.Given(aTasklist(&tasks, dummyTask)),
-		s.And(queryTasksIn(time.Minute*1, todo.PendingTasksQuery, dummyTask)),
-		s.And(func(suite **BDTemporalTestSuite) {
-			st := *suite
-			act := &todo2.Activities{}
-			st.Env.OnActivity(act.FetchUser, mock.Anything, dummyUser.SamAccountName).Return(dummyUser, nil).Times(everyDayReminderCount)
-			wfData := &todo2.WorkflowData{
-				AssignedSince: time.Minute * 60,
-			}
-			st.Env.OnActivity(act.CollectWorkflowData, mock.Anything, "default-test-workflow-id").Return(wfData, nil).Times(everyDayReminderCount)
-			st.Env.OnActivity(act.SendTaskReminder, mock.Anything, mock.Anything, []string{dummyUser.EmailAddress}).Return(nil).Times(everyDayReminderCount)
-		}),
+		s.Given(aTasklist(&tasks, revisedTask())),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.AddTaskSignal, MustMarshalAny(&todopb.AddTodoRequest{
+			Item: &todopb.TodoItem{Uuid: "t2", Description: "another task", CreatedBy: dummyUser.SamAccountName, Revision: 7},
+		}), nil)),
+		s.And(revisionIn(time.Minute*2, "t2", 1)),
 		s.When(startAWorkflow(&tasks)),
 		s.Then(WorkflowContinuedAsNew()),
 	)
-	s.Scenario("reminder every day update to less frequent",
+	s.Scenario("adding a todo starts over the state kept by the tasklist",
 		s.setupMocks,
-		s.Given(aTasklist(&tasks, dummyTask)),
-		s.And(func(suite **BDTemporalTestSuite) {
-			st := *suite
-			act := &todo2.Activities{}
-			st.Env.OnActivity(act.FetchUser, mock.Anything, dummyUser.SamAccountName).Return(dummyUser, nil).Times(2)
-			wfData := &todo2.WorkflowData{
-				AssignedSince: time.Minute * 60,
-			}
-			st.Env.OnActivity(act.CollectWorkflowData, mock.Anything, "default-test-workflow-id").Return(wfData, nil).Times(2)
-			st.Env.OnActivity(act.SendTaskReminder, mock.Anything, mock.Anything, []string{dummyUser.EmailAddress}).Return(nil).Times(2)
-		}),
-		s.And(ProxySignalSucceeded(time.Hour*49, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+		s.Given(aTasklist(&tasks, revisedTask())),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.AddTaskSignal, MustMarshalAny(&todopb.AddTodoRequest{
 			Item: &todopb.TodoItem{
-				Uuid:        "t1",
-				Description: "some task",
-				CreatedBy:   dummyUser.SamAccountName,
-				CreatedAt:   timestamppb.Now(),
-				Reminder: &todopb.Reminder{
-					Every: (time.Hour * 168).String(),
-				},
+				Uuid:            "t2",
+				Description:     "another task",
+				CreatedBy:       dummyUser.SamAccountName,
+				RemindersSent:   5,
+				ReminderHistory: []*todopb.ReminderAttempt{{Kind: "reminder"}},
+				SnoozedUntil:    timestamppb.Now(),
+				EscalationLevel: 2,
+				EscalationsSent: 3,
+				DueSoonSent:     true,
+				OverdueSent:     true,
+				Occurrence:      4,
+				NextOccurrence:  "t3",
 			},
 		}), nil)),
+		s.And(occurrencesIn(time.Minute*2, func(st *BDTemporalTestSuite, items []*todopb.TodoItem) {
+			if st.Len(items, 2) {
+				st.Empty(cmp.Diff(&todopb.TodoItem{
+					Uuid:        "t2",
+					Description: "another task",
+					CreatedBy:   dummyUser.SamAccountName,
+					Revision:    1,
+				}, items[1], protocmp.Transform(), protocmp.IgnoreFields(&todopb.TodoItem{}, "auditTrail")))
+			}
+		})),
 		s.When(startAWorkflow(&tasks)),
 		s.Then(WorkflowContinuedAsNew()),
 	)
-	s.Scenario("reminder in 2 days",
+	s.Scenario("updating a todo at the expected revision increments it",
 		s.setupMocks,
-		s.Given(aTasklist(&tasks, dummyTaskWith1Reminder)),
-		s.And(func(suite **BDTemporalTestSuite) {
-			st := *suite
-			act := &todo2.Activities{}
-			st.Env.OnActivity(act.FetchUser, mock.Anything, dummyUser.SamAccountName).Return(dummyUser, nil).Times(1)
-			wfData := &todo2.WorkflowData{
-				AssignedSince: time.Minute * 60,
+		s.Given(aTasklist(&tasks, revisedTask())),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item:             &todopb.TodoItem{Uuid: "t1", Description: "some task changed"},
+			ExpectedRevision: 3,
+		}), nil)),
+		s.And(revisionIn(time.Minute*2, "t1", 4)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("updating a todo without an expected revision increments it",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, revisedTask())),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item: &todopb.TodoItem{Uuid: "t1", Description: "some task changed"},
+		}), nil)),
+		s.And(revisionIn(time.Minute*2, "t1", 4)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("updating a todo at a stale revision is a conflict",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, revisedTask())),
+		s.And(ProxySignalErrored(time.Minute*1, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item:             &todopb.TodoItem{Uuid: "t1", Description: "some task changed"},
+			ExpectedRevision: 2,
+		}), "revision conflict: todo t1 is at revision 3, not 2")),
+		s.And(queryTasksIn(time.Minute*2, todo.AllTasksQuery, revisedTask())),
+		s.And(revisionIn(time.Minute*2, "t1", 3)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("deleting a todo at a stale revision is a conflict",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, revisedTask())),
+		s.And(ProxySignalErrored(time.Minute*1, todo.DeleteTaskSignal, MustMarshalAny(&todopb.DeleteTodoRequest{
+			Uuid:             "t1",
+			ExpectedRevision: 4,
+		}), "revision conflict: todo t1 is at revision 3, not 4")),
+		s.And(queryTasksIn(time.Minute*2, todo.AllTasksQuery, revisedTask())),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+}
+
+func (s *TasklistTestSuite) Test_Subtasks() {
+	var tasks *todo.Tasks
+	otherTask := &todopb.TodoItem{Uuid: "t9", Description: "some other task", CreatedBy: dummyUser.SamAccountName}
+	checklist := func(parentCompleted bool, completed ...bool) []*todopb.TodoItem {
+		parent := &todopb.TodoItem{
+			Uuid:                 "t1",
+			Description:          "some task",
+			CreatedBy:            dummyUser.SamAccountName,
+			CompleteWithSubtasks: true,
+		}
+		if parentCompleted {
+			parent.CompletedAt, parent.CompletedBy = timestamppb.Now(), dummyUser.SamAccountName
+		}
+		items := []*todopb.TodoItem{parent}
+		for i, c := range completed {
+			item := &todopb.TodoItem{
+				Uuid:        fmt.Sprintf("t1.%d", i+1),
+				Description: fmt.Sprintf("step %d", i+1),
+				CreatedBy:   dummyUser.SamAccountName,
+				ParentUuid:  "t1",
 			}
-			st.Env.OnActivity(act.CollectWorkflowData, mock.Anything, "default-test-workflow-id").Return(wfData, nil).Times(1)
-			st.Env.OnActivity(act.SendTaskReminder, mock.Anything, mock.Anything, []string{dummyUser.EmailAddress}).Return(nil).Times(1)
-		}),
+			if c {
+				item.CompletedAt, item.CompletedBy = timestamppb.Now(), dummyUser.SamAccountName
+			}
+			items = append(items, item)
+		}
+		return append(items, otherTask)
+	}
+	s.Scenario("adding a subtask to an unknown todo fails",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, otherTask)),
+		s.And(ProxySignalErrored(time.Minute*1, todo.AddTaskSignal, MustMarshalAny(&todopb.AddTodoRequest{
+			Item: &todopb.TodoItem{Uuid: "t2", Description: "step 1", ParentUuid: "t1"},
+		}), "task not found: t1")),
 		s.When(startAWorkflow(&tasks)),
 		s.Then(WorkflowContinuedAsNew()),
 	)
-	s.Scenario("reminder every day after 4 days",
+	s.Scenario("adding a todo with the uuid of another todo fails",
 		s.setupMocks,
-		s.Given(aTasklist(&tasks, dummyTaskWithDailyReminderAfter)),
+		s.Given(aTasklist(&tasks, checklist(false, false)...)),
+		s.And(ProxySignalErrored(time.Minute*1, todo.AddTaskSignal, MustMarshalAny(&todopb.AddTodoRequest{
+			Item: &todopb.TodoItem{Uuid: "t1", Description: "some task again", ParentUuid: "t1.1"},
+		}), "task exists already: t1")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("adding a todo without uuid fails",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, otherTask)),
+		s.And(ProxySignalErrored(time.Minute*1, todo.AddTaskSignal, MustMarshalAny(&todopb.AddTodoRequest{
+			Item: &todopb.TodoItem{Description: "some task"},
+		}), "uuid is missing")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("deleting a todo whose parents form a cycle deletes each of them once",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks,
+			&todopb.TodoItem{Uuid: "t1", Description: "some task", ParentUuid: "t2", CompleteWithSubtasks: true},
+			&todopb.TodoItem{Uuid: "t2", Description: "some subtask", ParentUuid: "t1", CompleteWithSubtasks: true},
+			otherTask)),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.DeleteTaskSignal, MustMarshalAny(&todopb.DeleteTodoRequest{
+			Uuid: "t1",
+		}), nil)),
+		s.And(queryTasksIn(time.Minute*2, todo.AllTasksQuery, otherTask)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("completing a todo whose parents form a cycle completes each of them once",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks,
+			&todopb.TodoItem{Uuid: "t1", Description: "some task", ParentUuid: "t2", CompleteWithSubtasks: true},
+			&todopb.TodoItem{Uuid: "t2", Description: "some subtask", ParentUuid: "t1", CompleteWithSubtasks: true},
+			otherTask)),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item:       &todopb.TodoItem{Uuid: "t2", CompletedAt: timestamppb.Now(), CompletedBy: dummyUser.SamAccountName},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"completedAt", "completedBy"}},
+		}), nil)),
+		s.And(queryTasksIn(time.Minute*2, todo.PendingTasksQuery, otherTask)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("adding a todo with nested subtasks fails",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, otherTask)),
+		s.And(ProxySignalErrored(time.Minute*1, todo.AddTaskSignal, MustMarshalAny(&todopb.AddTodoRequest{
+			Item: &todopb.TodoItem{Uuid: "t1", Description: "some task", Subtasks: []*todopb.TodoItem{{Uuid: "t2"}}},
+		}), "subtasks are added on their own")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("completing the last open subtask completes the parent",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, checklist(false, true, false)...)),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item:       &todopb.TodoItem{Uuid: "t1.2", CompletedAt: timestamppb.Now(), CompletedBy: dummyUser.SamAccountName},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"completedAt", "completedBy"}},
+		}), nil)),
+		s.And(queryTasksIn(time.Minute*2, todo.PendingTasksQuery, otherTask)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("reopening a subtask reopens the parent",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, checklist(true, true, true)...)),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item:       &todopb.TodoItem{Uuid: "t1.1"},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"completedAt", "completedBy"}},
+		}), nil)),
+		s.And(queryTasksIn(time.Minute*2, todo.PendingTasksQuery, checklist(false, false)...)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("adding an open subtask reopens the parent",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, checklist(true, true)...)),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.AddTaskSignal, MustMarshalAny(&todopb.AddTodoRequest{
+			Item: &todopb.TodoItem{Uuid: "t1.2", Description: "step 2", ParentUuid: "t1"},
+		}), nil)),
+		s.And(queryTasksIn(time.Minute*2, todo.PendingTasksQuery, checklist(false)[0], otherTask, &todopb.TodoItem{Uuid: "t1.2", Description: "step 2"})),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("deleting the last open subtask completes the parent",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, checklist(false, true, false)...)),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.DeleteTaskSignal, MustMarshalAny(&todopb.DeleteTodoRequest{
+			Uuid: "t1.2",
+		}), nil)),
+		s.And(queryTasksIn(time.Minute*2, todo.PendingTasksQuery, otherTask)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("deleting a todo deletes its subtasks",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, append(checklist(false, false, true), &todopb.TodoItem{
+			Uuid:        "t1.1.1",
+			Description: "step 1.1",
+			ParentUuid:  "t1.1",
+		})...)),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.DeleteTaskSignal, MustMarshalAny(&todopb.DeleteTodoRequest{
+			Uuid: "t1",
+		}), nil)),
+		s.And(queryTasksIn(time.Minute*2, todo.AllTasksQuery, otherTask)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("open subtasks of a completed todo keep the tasklist running",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, checklist(true, false)[:2]...)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("completing the last open subtask finishes the workflow",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, checklist(false, true, false)[:3]...)),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item:       &todopb.TodoItem{Uuid: "t1.2", CompletedAt: timestamppb.Now(), CompletedBy: dummyUser.SamAccountName},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"completedAt", "completedBy"}},
+		}), nil)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowFinished()),
+	)
+	s.Scenario("the parent of a todo can't be updated",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, checklist(false, false)...)),
+		s.And(ProxySignalErrored(time.Minute*1, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item:       &todopb.TodoItem{Uuid: "t1.1", ParentUuid: "t9"},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"parentUuid"}},
+		}), "invalid update mask: parentUuid: the field is immutable")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("the tree query nests subtasks under their parents",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, append(checklist(false, false, true), &todopb.TodoItem{
+			Uuid:        "t1.1.1",
+			Description: "step 1.1",
+			ParentUuid:  "t1.1",
+		})...)),
 		s.And(func(suite **BDTemporalTestSuite) {
 			st := *suite
-			act := &todo2.Activities{}
-			callCount := todo.MaxHoursPerRun/oneDayHours - afterDays
-			st.Env.OnActivity(act.FetchUser, mock.Anything, dummyUser.SamAccountName).Return(dummyUser, nil).Times(callCount)
-			wfData := &todo2.WorkflowData{
-				AssignedSince: time.Minute * 60,
-			}
-			st.Env.OnActivity(act.CollectWorkflowData, mock.Anything, "default-test-workflow-id").Return(wfData, nil).Times(callCount)
-			st.Env.OnActivity(act.SendTaskReminder, mock.Anything, mock.Anything, []string{dummyUser.EmailAddress}).Return(nil).Times(callCount)
+			st.Env.RegisterDelayedCallback(func() {
+				value, err := st.Env.QueryWorkflow(todo.TaskTreeQuery)
+				st.NoError(err)
+				var items []*todopb.TodoItem
+				st.NoError(value.Get(&items))
+				st.Len(items, 2)
+				st.Equal([]string{"t1", "t9"}, []string{items[0].Uuid, items[1].Uuid})
+				st.Len(items[0].Subtasks, 2)
+				st.Equal([]string{"t1.1", "t1.2"}, []string{items[0].Subtasks[0].Uuid, items[0].Subtasks[1].Uuid})
+				st.Len(items[0].Subtasks[0].Subtasks, 1)
+				st.Equal("t1.1.1", items[0].Subtasks[0].Subtasks[0].Uuid)
+			}, time.Minute*1)
 		}),
+		s.And(queryTasksIn(time.Minute*2, todo.AllTasksQuery, append(checklist(false, false, true), &todopb.TodoItem{
+			Uuid:        "t1.1.1",
+			Description: "step 1.1",
+		})...)),
 		s.When(startAWorkflow(&tasks)),
 		s.Then(WorkflowContinuedAsNew()),
 	)
 }
 
-func startAWorkflow(t **todo.Tasks) func(suite **BDTemporalTestSuite) {
-	return func(suite **BDTemporalTestSuite) {
+func (s *TasklistTestSuite) Test_Blockers() {
+	var tasks *todo.Tasks
+	start := time.Date(2021, 8, 10, 10, 0, 0, 0, time.UTC)
+	blocker := func(uuid string) *todopb.TodoItem {
+		return &todopb.TodoItem{Uuid: uuid, Description: "some blocker", CreatedBy: dummyUser.SamAccountName}
+	}
+	blockedTask := func(reminder *todopb.Reminder, blockedBy ...string) *todopb.TodoItem {
+		return &todopb.TodoItem{
+			Uuid:        "t2",
+			Description: "some blocked task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.New(start),
+			Reminder:    reminder,
+			BlockedBy:   blockedBy,
+		}
+	}
+	completeBlocker := func(uuid string) *anypb.Any {
+		return MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item:       &todopb.TodoItem{Uuid: uuid, CompletedAt: timestamppb.New(start.Add(time.Hour)), CompletedBy: dummyUser.SamAccountName},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"completedAt", "completedBy"}},
+		})
+	}
+	s.Scenario("blocked todos are not reminded about",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, blocker("t1"), blockedTask(&todopb.Reminder{At: timestamppb.New(start.Add(time.Hour))}, "t1"))),
+		s.And(queryTasksIn(time.Hour*2, todo.BlockedTasksQuery, blockedTask(nil))),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("completing the blocker notifies the blocked todo can be started",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, blocker("t1"), blockedTask(nil, "t1"))),
+		s.And(aDeliveryPolicy(nil)),
+		s.And(unblockedNotifiedAt(0, start.Add(time.Hour))),
+		s.And(ProxySignalSucceeded(time.Hour, todo.UpdateTaskSignal, completeBlocker("t1"), nil)),
+		s.And(queryTasksIn(time.Hour*2, todo.BlockedTasksQuery)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("completing one of two blockers notifies the todo is still blocked",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, blocker("t1"), blocker("t3"), blockedTask(nil, "t1", "t3"))),
+		s.And(aDeliveryPolicy(nil)),
+		s.And(unblockedNotifiedAt(1, start.Add(time.Hour))),
+		s.And(ProxySignalSucceeded(time.Hour, todo.UpdateTaskSignal, completeBlocker("t1"), nil)),
+		s.And(queryTasksIn(time.Hour*2, todo.BlockedTasksQuery, blockedTask(nil))),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("deleting the blocker unblocks the todo without notification",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, blocker("t1"), blockedTask(nil, "t1"))),
+		s.And(ProxySignalSucceeded(time.Hour, todo.DeleteTaskSignal, MustMarshalAny(&todopb.DeleteTodoRequest{
+			Uuid: "t1",
+		}), nil)),
+		s.And(queryTasksIn(time.Hour*2, todo.BlockedTasksQuery)),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			st.Env.RegisterDelayedCallback(func() {
+				value, err := st.Env.QueryWorkflow(todo.AllTasksQuery)
+				st.NoError(err)
+				var items []*todopb.TodoItem
+				st.NoError(value.Get(&items))
+				if st.Len(items, 1) {
+					st.Empty(items[0].BlockedBy)
+				}
+			}, time.Hour*2)
+		}),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("adding a todo blocked by an unknown todo fails",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, blocker("t1"))),
+		s.And(ProxySignalErrored(time.Minute*1, todo.AddTaskSignal, MustMarshalAny(&todopb.AddTodoRequest{
+			Item: blockedTask(nil, "t9"),
+		}), "invalid blockedBy: task not found: t9")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("a todo blocking itself is rejected",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, blocker("t1"))),
+		s.And(ProxySignalErrored(time.Minute*1, todo.AddTaskSignal, MustMarshalAny(&todopb.AddTodoRequest{
+			Item: blockedTask(nil, "t1", "t2"),
+		}), "invalid blockedBy: t2 can't block itself")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("blockers waiting for each other are rejected",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, blocker("t1"), &todopb.TodoItem{Uuid: "t3", Description: "some task", BlockedBy: []string{"t1"}}, blockedTask(nil, "t3"))),
+		s.And(ProxySignalErrored(time.Minute*1, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item:       &todopb.TodoItem{Uuid: "t1", BlockedBy: []string{"t2"}},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"blockedBy"}},
+		}), "invalid blockedBy: t2 waits for t1 already")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+}
+
+func (s *TasklistTestSuite) Test_Recurrence() {
+	var tasks *todo.Tasks
+	start := time.Date(2021, 8, 10, 10, 0, 0, 0, time.UTC)
+	day := time.Hour * 24
+	recurringTask := func(recurrence string, dueAt time.Time, occurrence int32) *todopb.TodoItem {
+		return &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.New(start),
+			DueAt:       timestamppb.New(dueAt),
+			Recurrence:  recurrence,
+			Occurrence:  occurrence,
+		}
+	}
+	complete := func(uuid string) *anypb.Any {
+		return MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item:       &todopb.TodoItem{Uuid: uuid, CompletedAt: timestamppb.New(start.Add(time.Hour)), CompletedBy: dummyUser.SamAccountName},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"completedAt", "completedBy"}},
+		})
+	}
+	s.Scenario("completing a recurring todo adds its next occurrence",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, func() *todopb.TodoItem {
+			item := recurringTask("FREQ=WEEKLY", start.Add(day*10), 1)
+			// after the end of the run
+			item.Reminder = &todopb.Reminder{At: timestamppb.New(start.Add(day * 9))}
+			return item
+		}())),
+		s.And(ProxySignalSucceeded(time.Hour, todo.UpdateTaskSignal, complete("t1"), nil)),
+		s.And(occurrencesIn(time.Hour*2, func(st *BDTemporalTestSuite, items []*todopb.TodoItem) {
+			if !st.Len(items, 2) {
+				return
+			}
+			archived, next := items[0], items[1]
+			st.NotNil(archived.CompletedAt)
+			st.Equal(next.Uuid, archived.NextOccurrence)
+			st.NotEqual("t1", next.Uuid)
+			st.NotEmpty(next.Uuid)
+			st.Nil(next.CompletedAt)
+			st.Equal(int32(2), next.Occurrence)
+			st.Equal(int64(1), next.Revision)
+			st.True(start.Add(day*17).Equal(next.DueAt.AsTime()), "next occurrence due at %s", next.DueAt.AsTime())
+			st.True(start.Add(day*16).Equal(next.Reminder.At.AsTime()), "next reminder at %s", next.Reminder.At.AsTime())
+			st.Equal("some task", next.Description)
+		})),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	recurringParent := func() *todopb.TodoItem {
+		item := recurringTask("FREQ=WEEKLY", start.Add(day*10), 1)
+		item.CompleteWithSubtasks = true
+		// after the end of the run
+		item.Reminder = &todopb.Reminder{At: timestamppb.New(start.Add(day * 9))}
+		return item
+	}
+	completedByItsSubtasks := func(st *BDTemporalTestSuite, items []*todopb.TodoItem) {
+		var occurrences []*todopb.TodoItem
+		for _, item := range items {
+			if item.Description == "some task" {
+				occurrences = append(occurrences, item)
+			}
+		}
+		if st.Len(occurrences, 2) {
+			st.NotNil(occurrences[0].CompletedAt)
+			st.Equal(occurrences[1].Uuid, occurrences[0].NextOccurrence)
+			st.Equal(int32(2), occurrences[1].Occurrence)
+		}
+	}
+	s.Scenario("deleting the last open subtask of a recurring todo adds its next occurrence",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, recurringParent(),
+			&todopb.TodoItem{Uuid: "t1.1", Description: "step 1", ParentUuid: "t1", CompletedAt: timestamppb.New(start)},
+			&todopb.TodoItem{Uuid: "t1.2", Description: "step 2", ParentUuid: "t1"})),
+		s.And(ProxySignalSucceeded(time.Hour, todo.DeleteTaskSignal, MustMarshalAny(&todopb.DeleteTodoRequest{
+			Uuid: "t1.2",
+		}), nil)),
+		s.And(occurrencesIn(time.Hour*2, completedByItsSubtasks)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("adding a completed subtask completing a recurring todo adds its next occurrence",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, recurringParent())),
+		s.And(ProxySignalSucceeded(time.Hour, todo.AddTaskSignal, MustMarshalAny(&todopb.AddTodoRequest{
+			Item: &todopb.TodoItem{
+				Uuid:        "t1.1",
+				Description: "step 1",
+				ParentUuid:  "t1",
+				CompletedAt: timestamppb.New(start.Add(time.Hour)),
+				CompletedBy: dummyUser.SamAccountName,
+			},
+		}), nil)),
+		s.And(occurrencesIn(time.Hour*2, completedByItsSubtasks)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("completing a recurring todo late skips the missed occurrences",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, recurringTask("FREQ=WEEKLY;INTERVAL=2", start.Add(-day*15), 1))),
+		s.And(aDeliveryPolicy(nil)),
+		s.And(dueNotifiedOn(map[string]time.Duration{
+			"overdue": -day * 15,
+		}, start)),
+		s.And(ProxySignalSucceeded(time.Hour, todo.UpdateTaskSignal, complete("t1"), nil)),
+		s.And(occurrencesIn(time.Hour*2, func(st *BDTemporalTestSuite, items []*todopb.TodoItem) {
+			if st.Len(items, 2) {
+				st.Equal(int32(3), items[1].Occurrence)
+				st.True(start.Add(day*13).Equal(items[1].DueAt.AsTime()), "next occurrence due at %s", items[1].DueAt.AsTime())
+			}
+		})),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("completing the last occurrence finishes the workflow",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, recurringTask("FREQ=WEEKLY;COUNT=2", start.Add(day*10), 2))),
+		s.And(ProxySignalSucceeded(time.Hour, todo.UpdateTaskSignal, complete("t1"), nil)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowFinished()),
+	)
+	s.Scenario("completing an occurrence again keeps its next occurrence",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, func() *todopb.TodoItem {
+			item := recurringTask("FREQ=WEEKLY", start.Add(day*10), 1)
+			item.NextOccurrence = "t2"
+			return item
+		}(), recurringTask("FREQ=WEEKLY", start.Add(day*17), 2))),
+		s.And(ProxySignalSucceeded(time.Hour, todo.UpdateTaskSignal, complete("t1"), nil)),
+		s.And(occurrencesIn(time.Hour*2, func(st *BDTemporalTestSuite, items []*todopb.TodoItem) {
+			st.Len(items, 2)
+		})),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("a recurring todo needs a due date",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, recurringTask("", start.Add(day*10), 0))),
+		s.And(ProxySignalErrored(time.Minute*1, todo.AddTaskSignal, MustMarshalAny(&todopb.AddTodoRequest{
+			Item: &todopb.TodoItem{Uuid: "t2", Description: "some task", Recurrence: "FREQ=DAILY"},
+		}), "invalid recurrence: a recurring todo needs a dueAt")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("an unsupported recurrence is rejected",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, recurringTask("", start.Add(day*10), 0))),
+		s.And(ProxySignalErrored(time.Minute*1, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item:       &todopb.TodoItem{Uuid: "t1", Recurrence: "FREQ=HOURLY"},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"recurrence"}},
+		}), "invalid recurrence: unsupported FREQ HOURLY")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+}
+
+func (s *TasklistTestSuite) Test_Assignees() {
+	var tasks *todo.Tasks
+	start := time.Date(2021, 8, 10, 10, 0, 0, 0, time.UTC)
+	assignee := &todopb.ADUser{DisplayName: "Doe Jane", EmailAddress: "jane.doe@domain.com", SamAccountName: "jdoe"}
+	watcher := &todopb.ADUser{DisplayName: "Smith Anna", EmailAddress: "anna.smith@domain.com", SamAccountName: "asmith"}
+	userNotFound := temporal.NewNonRetryableApplicationError("user not found", "UserNotFound", nil)
+	taskRemindedAt := func(at time.Time, assignee string, watchers ...string) *todopb.TodoItem {
+		return &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.New(start),
+			Reminder:    &todopb.Reminder{At: timestamppb.New(at)},
+			Assignee:    assignee,
+			Watchers:    watchers,
+		}
+	}
+	unremindedTask := &todopb.TodoItem{Uuid: "t1", Description: "some task", CreatedBy: dummyUser.SamAccountName, Revision: 1}
+	s.Scenario("reminders go to the assignee with the watchers in cc",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, taskRemindedAt(start.Add(time.Hour), "jdoe", "asmith", "nobody", "jdoe"))),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			act := &todo2.Activities{}
+			st.Env.OnActivity(act.FetchUser, mock.Anything, "asmith").Return(watcher, nil).Once()
+			st.Env.OnActivity(act.FetchUser, mock.Anything, "nobody").Return(nil, userNotFound).Once()
+		}),
+		s.And(remindedAssignee(assignee, []string{watcher.EmailAddress}, 1)),
+		s.And(aDeliveryPolicy(nil)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("reassigning routes the next reminder to the new assignee",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, taskRemindedAt(start.Add(time.Hour*2), ""))),
+		s.And(remindedAssignee(assignee, nil, 2)),
+		s.And(aDeliveryPolicy(nil)),
+		s.And(ProxySignalSucceeded(time.Hour, todo.ReassignTaskSignal, MustMarshalAny(&todopb.ReassignTodoRequest{
+			Uuid:     "t1",
+			Assignee: "jdoe",
+		}), nil)),
+		s.And(revisionIn(time.Hour*3, "t1", 1)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("reassigning after reminders the new assignee starts over without escalating",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, func() *todopb.TodoItem {
+			task := taskRemindedAt(start.Add(time.Hour*2), "")
+			task.RemindersSent = 3
+			task.Escalation = &todopb.Escalation{Levels: []*todopb.EscalationLevel{{
+				Target: todopb.EscalationLevel_MANAGER,
+				After:  2,
+				Every:  (time.Hour * 24).String(),
+			}}}
+			return task
+		}())),
+		s.And(remindedAssignee(assignee, nil, 2)),
+		s.And(aDeliveryPolicy(nil)),
+		s.And(ProxySignalSucceeded(time.Hour, todo.ReassignTaskSignal, MustMarshalAny(&todopb.ReassignTodoRequest{
+			Uuid:     "t1",
+			Assignee: "jdoe",
+		}), nil)),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			st.Env.RegisterDelayedCallback(func() {
+				value, err := st.Env.QueryWorkflow(todo.AllTasksQuery)
+				st.NoError(err)
+				var items []*todopb.TodoItem
+				st.NoError(value.Get(&items))
+				st.Equal(int32(1), items[0].RemindersSent)
+				st.Equal(int32(0), items[0].EscalationLevel)
+			}, time.Hour*3)
+		}),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("reassigning to a user unknown to the directory fails",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, unremindedTask)),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			act := &todo2.Activities{}
+			st.Env.OnActivity(act.FetchUser, mock.Anything, "nobody").Return(nil, userNotFound).Once()
+		}),
+		s.And(ProxySignalErrored(time.Minute*1, todo.ReassignTaskSignal, MustMarshalAny(&todopb.ReassignTodoRequest{
+			Uuid:     "t1",
+			Assignee: "nobody",
+		}), "unknown assignee nobody")),
+		s.And(revisionIn(time.Minute*2, "t1", 1)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("reassigning while the directory fails reports the failed lookup",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, unremindedTask)),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			act := &todo2.Activities{}
+			st.Env.OnActivity(act.FetchUser, mock.Anything, "jdoe").Return(nil, temporal.NewNonRetryableApplicationError("timeout", "Timeout", nil)).Once()
+		}),
+		s.And(ProxySignalErrored(time.Minute*1, todo.ReassignTaskSignal, MustMarshalAny(&todopb.ReassignTodoRequest{
+			Uuid:     "t1",
+			Assignee: "jdoe",
+		}), "looking up assignee jdoe failed")),
+		s.And(revisionIn(time.Minute*2, "t1", 1)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("adding a todo assigned to a user unknown to the directory fails",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, unremindedTask)),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			act := &todo2.Activities{}
+			st.Env.OnActivity(act.FetchUser, mock.Anything, "nobody").Return(nil, userNotFound).Once()
+		}),
+		s.And(ProxySignalErrored(time.Minute*1, todo.AddTaskSignal, MustMarshalAny(&todopb.AddTodoRequest{
+			Item: &todopb.TodoItem{Uuid: "t2", Description: "some other task", Assignee: "nobody"},
+		}), "unknown assignee nobody")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("the assignee can't be updated",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, unremindedTask)),
+		s.And(ProxySignalErrored(time.Minute*1, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item:       &todopb.TodoItem{Uuid: "t1", Assignee: "jdoe"},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"assignee"}},
+		}), "invalid update mask: assignee: the field is changed by reassigning the todo")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+}
+
+func (s *TasklistTestSuite) Test_History() {
+	var tasks *todo.Tasks
+	start := time.Date(2021, 8, 10, 10, 0, 0, 0, time.UTC)
+	task := func() *todopb.TodoItem {
+		return &todopb.TodoItem{Uuid: "t1", Description: "some task", CreatedBy: dummyUser.SamAccountName, Revision: 1}
+	}
+	s.Scenario("updating a todo records the changed fields",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, task())),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item:       &todopb.TodoItem{Uuid: "t1", Description: "some renamed task", Priority: todopb.TodoItem_HIGH, Tags: []string{"billing"}},
+			Owner:      "user1",
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"description", "priority", "tags", "labels"}},
+		}), nil)),
+		s.And(historyIn(time.Minute*2, "t1", func(st *BDTemporalTestSuite, history *todopb.TodoHistory) {
+			at := timestamppb.New(start.Add(time.Minute))
+			st.Empty(cmp.Diff([]*todopb.AuditEntry{
+				{At: at, Actor: "user1", Signal: todo.UpdateTaskSignal, Field: "description", From: "some task", To: "some renamed task"},
+				{At: at, Actor: "user1", Signal: todo.UpdateTaskSignal, Field: "priority", To: "HIGH"},
+				{At: at, Actor: "user1", Signal: todo.UpdateTaskSignal, Field: "tags", To: "[billing]"},
+			}, history.AuditTrail, protocmp.Transform()))
+		})),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("adding a todo records it was added",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, task())),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.AddTaskSignal, MustMarshalAny(&todopb.AddTodoRequest{
+			Item:  &todopb.TodoItem{Uuid: "t2", Description: "some other task", AuditTrail: []*todopb.AuditEntry{{Field: "forged"}}},
+			Owner: "user1",
+		}), nil)),
+		s.And(historyIn(time.Minute*2, "t2", func(st *BDTemporalTestSuite, history *todopb.TodoHistory) {
+			st.Empty(cmp.Diff([]*todopb.AuditEntry{
+				{At: timestamppb.New(start.Add(time.Minute)), Actor: "user1", Signal: todo.AddTaskSignal},
+			}, history.AuditTrail, protocmp.Transform()))
+		})),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("completing the last subtask records the completion of the parent",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, &todopb.TodoItem{Uuid: "t1", Description: "some task", CompleteWithSubtasks: true},
+			&todopb.TodoItem{Uuid: "t1.1", Description: "step 1", ParentUuid: "t1"},
+			&todopb.TodoItem{Uuid: "t2", Description: "some other task"})),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item:       &todopb.TodoItem{Uuid: "t1.1", CompletedAt: timestamppb.New(start.Add(time.Minute)), CompletedBy: "user1"},
+			Owner:      "user1",
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"completedAt", "completedBy"}},
+		}), nil)),
+		s.And(historyIn(time.Minute*2, "t1", func(st *BDTemporalTestSuite, history *todopb.TodoHistory) {
+			at := timestamppb.New(start.Add(time.Minute))
+			st.Empty(cmp.Diff([]*todopb.AuditEntry{
+				{At: at, Actor: "user1", Signal: todo.UpdateTaskSignal, Field: "completedAt", To: "2021-08-10T10:01:00Z"},
+				{At: at, Actor: "user1", Signal: todo.UpdateTaskSignal, Field: "completedBy", To: "user1"},
+			}, history.AuditTrail, protocmp.Transform()))
+		})),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("comments are added to the history",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, task())),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.AddCommentSignal, MustMarshalAny(&todopb.AddCommentRequest{
+			Uuid:  "t1",
+			Owner: "user1",
+			Text:  "waiting for the invoice",
+		}), nil)),
+		s.And(historyIn(time.Minute*2, "t1", func(st *BDTemporalTestSuite, history *todopb.TodoHistory) {
+			at := timestamppb.New(start.Add(time.Minute))
+			st.Empty(cmp.Diff([]*todopb.Comment{
+				{Author: "user1", Text: "waiting for the invoice", At: at},
+			}, history.Comments, protocmp.Transform()))
+			st.Empty(cmp.Diff([]*todopb.AuditEntry{
+				{At: at, Actor: "user1", Signal: todo.AddCommentSignal, Field: "comments", To: "waiting for the invoice"},
+			}, history.AuditTrail, protocmp.Transform()))
+		})),
+		s.And(revisionIn(time.Minute*2, "t1", 2)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("the oldest changes beyond the audit trail are reported as dropped",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, func() *todopb.TodoItem {
+			item := task()
+			for i := 0; i < 50; i++ {
+				item.AuditTrail = append(item.AuditTrail, &todopb.AuditEntry{Signal: todo.UpdateTaskSignal, Field: "description"})
+			}
+			return item
+		}())),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.AddCommentSignal, MustMarshalAny(&todopb.AddCommentRequest{
+			Uuid:  "t1",
+			Owner: "user1",
+			Text:  "waiting for the invoice",
+		}), nil)),
+		s.And(historyIn(time.Minute*2, "t1", func(st *BDTemporalTestSuite, history *todopb.TodoHistory) {
+			if st.Len(history.AuditTrail, 50) {
+				st.Equal(todo.AddCommentSignal, history.AuditTrail[49].Signal)
+			}
+			st.Equal(int32(1), history.AuditEntriesDropped)
+		})),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("an empty comment is rejected",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, task())),
+		s.And(ProxySignalErrored(time.Minute*1, todo.AddCommentSignal, MustMarshalAny(&todopb.AddCommentRequest{
+			Uuid: "t1",
+			Text: " ",
+		}), "comment is missing")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("the history of an unknown todo is not found",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, task())),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			st.Env.RegisterDelayedCallback(func() {
+				_, err := st.Env.QueryWorkflow(todo.TaskHistoryQuery, "t9")
+				st.EqualError(err, "task not found: t9")
+			}, time.Minute)
+		}),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+}
+
+func (s *TasklistTestSuite) Test_UpdateMask() {
+	var tasks *todo.Tasks
+	start := time.Date(2021, 8, 10, 10, 0, 0, 0, time.UTC)
+	remindedTask := func() *todopb.TodoItem {
+		return &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.New(start),
+			Reminder: &todopb.Reminder{
+				// after the end of the run
+				At: timestamppb.New(start.Add(time.Hour * 200)),
+			},
+		}
+	}
+	pendingTask := &todopb.TodoItem{
+		Uuid:        "t2",
+		Description: "some task 2",
+		CreatedBy:   dummyUser.SamAccountName,
+		CreatedAt:   timestamppb.New(start),
+	}
+	updatedWithMask := func(item *todopb.TodoItem, paths ...string) *anypb.Any {
+		return MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item:       item,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: paths},
+		})
+	}
+	firstTaskIn := func(delay time.Duration, check func(st *BDTemporalTestSuite, item *todopb.TodoItem)) func(suite **BDTemporalTestSuite) {
+		return func(suite **BDTemporalTestSuite) {
+			st := *suite
+			st.Env.RegisterDelayedCallback(func() {
+				value, err := st.Env.QueryWorkflow(todo.AllTasksQuery)
+				st.NoError(err)
+				var items []*todopb.TodoItem
+				st.NoError(value.Get(&items))
+				check(st, items[0])
+			}, delay)
+		}
+	}
+	s.Scenario("completing a todo with a mask keeps its reminder",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, remindedTask(), pendingTask)),
+		s.And(ProxySignalSucceeded(time.Minute, todo.UpdateTaskSignal, updatedWithMask(&todopb.TodoItem{
+			Uuid:        "t1",
+			CompletedAt: timestamppb.New(start.Add(time.Minute)),
+			CompletedBy: dummyUser.SamAccountName,
+		}, "completedAt", "completedBy"), nil)),
+		s.And(firstTaskIn(time.Minute*2, func(st *BDTemporalTestSuite, item *todopb.TodoItem) {
+			st.NotNil(item.CompletedAt)
+			st.Equal(dummyUser.SamAccountName, item.CompletedBy)
+			st.Equal("some task", item.Description)
+			st.True(proto.Equal(remindedTask().Reminder, item.Reminder), "reminder was changed to %v", item.Reminder)
+		})),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("updating a field of the reminder keeps the others",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, remindedTask(), pendingTask)),
+		s.And(ProxySignalSucceeded(time.Minute, todo.UpdateTaskSignal, updatedWithMask(&todopb.TodoItem{
+			Uuid: "t1",
+			Reminder: &todopb.Reminder{
+				Every: (time.Hour * 24).String(),
+			},
+		}, "reminder.every"), nil)),
+		s.And(firstTaskIn(time.Minute*2, func(st *BDTemporalTestSuite, item *todopb.TodoItem) {
+			st.Equal((time.Hour * 24).String(), item.Reminder.GetEvery())
+			st.True(start.Add(time.Hour*200).Equal(item.Reminder.GetAt().AsTime()), "reminder at %v", item.Reminder.GetAt())
+		})),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("updating an immutable field fails",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, remindedTask())),
+		s.And(ProxySignalErrored(time.Minute, todo.UpdateTaskSignal, updatedWithMask(&todopb.TodoItem{
+			Uuid:      "t1",
+			CreatedBy: "someone else",
+		}, "createdBy"), "invalid update mask: createdBy: the field is immutable")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("updating a field kept by the tasklist fails",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, remindedTask())),
+		s.And(ProxySignalErrored(time.Minute, todo.UpdateTaskSignal, updatedWithMask(&todopb.TodoItem{
+			Uuid:          "t1",
+			RemindersSent: 0,
+		}, "remindersSent"), "invalid update mask: remindersSent: the field is kept by the Tasklist")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("updating an unknown path fails",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, remindedTask())),
+		s.And(ProxySignalErrored(time.Minute, todo.UpdateTaskSignal, updatedWithMask(&todopb.TodoItem{
+			Uuid: "t1",
+		}, "reminder.colour"), "invalid update mask: reminder.colour: unknown field")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+}
+
+func (s *TasklistTestSuite) Test_Digest() {
+	var tasks *todo.Tasks
+	start := time.Date(2021, 8, 10, 10, 0, 0, 0, time.UTC)
+	dailyTasks := func() []*todopb.TodoItem {
+		return []*todopb.TodoItem{{
+			Uuid:        "t1",
+			Description: "some task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.New(start),
+			Reminder: &todopb.Reminder{
+				Every: (time.Hour * 24).String(),
+			},
+		}, {
+			Uuid:        "t2",
+			Description: "some task 2",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.New(start),
+			Reminder: &todopb.Reminder{
+				At:    timestamppb.New(start.Add(time.Hour * 26)),
+				Every: (time.Hour * 24).String(),
+			},
+		}}
+	}
+	s.Scenario("reminders due within the window are merged into one digest",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(func(suite **BDTemporalTestSuite) {
+			tasks = &todo.Tasks{
+				Items: dailyTasks(),
+				Digest: &todopb.Digest{
+					Window: (time.Hour * 6).String(),
+				},
+			}
+		}),
+		s.And(aDeliveryPolicy(nil)),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			act := &todo2.Activities{}
+			// t1 is due at 24h and t2 at 26h every day, the digests are sent at 30h, 54h, ... 150h
+			digests := 6
+			wfData := &todo2.WorkflowData{
+				OtherPending: 0,
+			}
+			st.Env.OnActivity(act.FetchUser, mock.Anything, dummyUser.SamAccountName).Return(dummyUser, nil).Times(digests)
+			st.Env.OnActivity(act.CollectDigestData, mock.Anything, mock.MatchedBy(func(items []*todopb.TodoItem) bool {
+				return len(items) == 2 && items[0].Uuid == "t1" && items[1].Uuid == "t2"
+			}), mock.Anything).Return(wfData, nil).Times(digests)
+			st.Env.OnActivity(act.PrepareReminderModel, mock.Anything, wfData, time.Duration(0), "", dummyUser).Return(dummyReminderModel, nil).Times(digests)
+			st.Env.OnActivity(act.SendTaskReminder, mock.Anything, dummyReminderModel, []string{dummyUser.EmailAddress}).Return(func(ctx context.Context, model *todopb.TaskReminderModel, addressee []string) error {
+				st.Equal(16, st.Env.Now().Hour(), "digest sent at "+st.Env.Now().String())
+				return nil
+			}).Times(digests)
+		}),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			st.Env.RegisterDelayedCallback(func() {
+				value, err := st.Env.QueryWorkflow(todo.AllTasksQuery)
+				st.NoError(err)
+				var items []*todopb.TodoItem
+				st.NoError(value.Get(&items))
+				st.Equal(int32(6), items[0].RemindersSent)
+				st.Equal(int32(6), items[1].RemindersSent)
+			}, time.Hour*160)
+		}),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("setting a digest preference",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.Now(),
+		})),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.SetDigestSignal, MustMarshalAny(&todopb.SetDigestRequest{
+			Digest: &todopb.Digest{Window: "24h"},
+		}), nil)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("setting an invalid digest window fails",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.Now(),
+		})),
+		s.And(ProxySignalErrored(time.Minute*1, todo.SetDigestSignal, MustMarshalAny(&todopb.SetDigestRequest{
+			Digest: &todopb.Digest{Window: "daily"},
+		}), "invalid digest window")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("a tasklist holding a digest preference keeps running without pending todos",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(func(suite **BDTemporalTestSuite) {
+			tasks = &todo.Tasks{
+				Items:  []*todopb.TodoItem{{Uuid: "t1", Description: "some task", CompletedAt: timestamppb.New(start)}},
+				Digest: &todopb.Digest{Window: (time.Hour * 6).String()},
+			}
+		}),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("turning the digest off closes a tasklist without pending todos",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(func(suite **BDTemporalTestSuite) {
+			tasks = &todo.Tasks{
+				Items:  []*todopb.TodoItem{{Uuid: "t1", Description: "some task", CompletedAt: timestamppb.New(start)}},
+				Digest: &todopb.Digest{Window: (time.Hour * 6).String()},
+			}
+		}),
+		s.And(ProxySignalSucceeded(time.Minute*1, todo.SetDigestSignal, MustMarshalAny(&todopb.SetDigestRequest{}), nil)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowFinished()),
+	)
+}
+
+func (s *TasklistTestSuite) Test_Escalation() {
+	var tasks *todo.Tasks
+	start := time.Date(2021, 8, 10, 10, 0, 0, 0, time.UTC)
+	manager := &todopb.ADUser{
+		DisplayName:    "Manager",
+		EmailAddress:   "manager@domain.com",
+		SamAccountName: "manager",
+	}
+	approvers := []*todopb.ADUser{
+		{DisplayName: "Approver 1", EmailAddress: "approver1@domain.com", SamAccountName: "approver1"},
+		{DisplayName: "Approver 2", EmailAddress: "approver2@domain.com", SamAccountName: "approver2"},
+	}
+	escalatingTask := func(levels ...*todopb.EscalationLevel) *todopb.TodoItem {
+		return &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.New(start),
+			Reminder: &todopb.Reminder{
+				Every: (time.Hour * 24).String(),
+			},
+			Escalation: &todopb.Escalation{
+				Levels: levels,
+			},
+		}
+	}
+	toManager := &todopb.EscalationLevel{
+		Target: todopb.EscalationLevel_MANAGER,
+		After:  2,
+		Every:  (time.Hour * 48).String(),
+	}
+	toApprovers := &todopb.EscalationLevel{
+		Target: todopb.EscalationLevel_GROUP,
+		Group:  "approvers",
+		After:  2,
+		Every:  (time.Hour * 30).String(),
+	}
+	s.Scenario("ignored reminders escalate to the manager and then the group",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, escalatingTask(toManager, toApprovers))),
+		s.And(aDeliveryPolicy(nil)),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			act := &todo2.Activities{}
+			wfData := &todo2.WorkflowData{
+				AssignedSince: time.Minute * 60,
+			}
+			// reminders at 24h to 144h, the manager at 48h and 96h, the approvers at 96h, 126h and 156h
+			reminders, managerNotifications, groupNotifications := 6, 2, 3
+			notifications := reminders + managerNotifications + groupNotifications
+			st.Env.OnActivity(act.FetchUser, mock.Anything, dummyUser.SamAccountName).Return(dummyUser, nil).Times(notifications)
+			st.Env.OnActivity(act.CollectWorkflowData, mock.Anything, taskWithUuid("t1"), mock.Anything).Return(wfData, nil).Times(notifications)
+			st.Env.OnActivity(act.PrepareReminderModel, mock.Anything, wfData, wfData.AssignedSince, "some task", dummyUser).Return(dummyReminderModel, nil).Times(reminders)
+			st.Env.OnActivity(act.SendTaskReminder, mock.Anything, dummyReminderModel, []string{dummyUser.EmailAddress}).Return(nil).Times(reminders)
+
+			managerModel := &todopb.TaskReminderModel{Subject: "Escalation to the manager"}
+			st.Env.OnActivity(act.FetchEscalationRecipients, mock.Anything, escalationTo(todopb.EscalationLevel_MANAGER), dummyUser.SamAccountName).Return([]*todopb.ADUser{manager}, nil).Times(managerNotifications)
+			st.Env.OnActivity(act.PrepareEscalationModel, mock.Anything, wfData, "manager", "some task", dummyUser, manager).Return(managerModel, nil).Times(managerNotifications)
+			st.Env.OnActivity(act.SendTaskReminder, mock.Anything, managerModel, []string{manager.EmailAddress}).Return(nil).Times(managerNotifications)
+
+			groupModel := &todopb.TaskReminderModel{Subject: "Escalation to the group"}
+			st.Env.OnActivity(act.FetchEscalationRecipients, mock.Anything, escalationTo(todopb.EscalationLevel_GROUP), dummyUser.SamAccountName).Return(approvers, nil).Times(groupNotifications)
+			for _, approver := range approvers {
+				st.Env.OnActivity(act.PrepareEscalationModel, mock.Anything, wfData, "group", "some task", dummyUser, approver).Return(groupModel, nil).Times(groupNotifications)
+				st.Env.OnActivity(act.SendTaskReminder, mock.Anything, groupModel, []string{approver.EmailAddress}).Return(nil).Times(groupNotifications)
+			}
+		}),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			st.Env.RegisterDelayedCallback(func() {
+				value, err := st.Env.QueryWorkflow(todo.AllTasksQuery)
+				st.NoError(err)
+				var items []*todopb.TodoItem
+				st.NoError(value.Get(&items))
+				st.Equal(int32(2), items[0].EscalationLevel)
+				st.Equal(int32(1), items[0].EscalationsSent)
+			}, time.Hour*100)
+		}),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("completing the todo stops the escalation",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, escalatingTask(&todopb.EscalationLevel{
+			Target: todopb.EscalationLevel_MANAGER,
+			After:  1,
+			Every:  (time.Hour * 24).String(),
+		}), &todopb.TodoItem{
+			Uuid:        "t2",
+			Description: "some task 2",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.New(start),
+		})),
+		s.And(aDeliveryPolicy(nil)),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			act := &todo2.Activities{}
+			wfData := &todo2.WorkflowData{
+				AssignedSince: time.Minute * 60,
+			}
+			st.Env.OnActivity(act.FetchUser, mock.Anything, dummyUser.SamAccountName).Return(dummyUser, nil).Times(2)
+			st.Env.OnActivity(act.CollectWorkflowData, mock.Anything, taskWithUuid("t1"), mock.Anything).Return(wfData, nil).Times(2)
+			st.Env.OnActivity(act.PrepareReminderModel, mock.Anything, wfData, wfData.AssignedSince, "some task", dummyUser).Return(dummyReminderModel, nil).Times(1)
+			st.Env.OnActivity(act.SendTaskReminder, mock.Anything, dummyReminderModel, []string{dummyUser.EmailAddress}).Return(nil).Times(1)
+			st.Env.OnActivity(act.FetchEscalationRecipients, mock.Anything, escalationTo(todopb.EscalationLevel_MANAGER), dummyUser.SamAccountName).Return([]*todopb.ADUser{manager}, nil).Times(1)
+			st.Env.OnActivity(act.PrepareEscalationModel, mock.Anything, wfData, "manager", "some task", dummyUser, manager).Return(dummyReminderModel, nil).Times(1)
+			st.Env.OnActivity(act.SendTaskReminder, mock.Anything, dummyReminderModel, []string{manager.EmailAddress}).Return(nil).Times(1)
+		}),
+		s.And(ProxySignalSucceeded(time.Hour*30, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item: &todopb.TodoItem{
+				Uuid:        "t1",
+				Description: "some task",
+				CompletedAt: timestamppb.New(start.Add(time.Hour * 30)),
+				CompletedBy: dummyUser.SamAccountName,
+				Reminder:    escalatingTask().Reminder,
+				Escalation: &todopb.Escalation{
+					Levels: []*todopb.EscalationLevel{{
+						Target: todopb.EscalationLevel_MANAGER,
+						After:  1,
+						Every:  (time.Hour * 24).String(),
+					}},
+				},
+			},
+		}), nil)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("adding a todo escalating without reminder fails",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, &todopb.TodoItem{
+			Uuid:        "t2",
+			Description: "some task 2",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.Now(),
+		})),
+		s.And(ProxySignalErrored(time.Minute*1, todo.AddTaskSignal, MustMarshalAny(&todopb.AddTodoRequest{
+			Item: &todopb.TodoItem{
+				Uuid:        "t1",
+				Description: "some task",
+				Escalation: &todopb.Escalation{
+					Levels: []*todopb.EscalationLevel{toManager},
+				},
+			},
+		}), "escalating requires a reminder")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("adding a todo with an invalid escalation cadence fails",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, &todopb.TodoItem{
+			Uuid:        "t2",
+			Description: "some task 2",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.Now(),
+		})),
+		s.And(ProxySignalErrored(time.Minute*1, todo.AddTaskSignal, MustMarshalAny(&todopb.AddTodoRequest{
+			Item: &todopb.TodoItem{
+				Uuid:        "t1",
+				Description: "some task",
+				Reminder: &todopb.Reminder{
+					Every: (time.Hour * 24).String(),
+				},
+				Escalation: &todopb.Escalation{
+					Levels: []*todopb.EscalationLevel{{After: 2, Every: "weekly"}},
+				},
+			},
+		}), "invalid escalation level 1 every")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+}
+
+func (s *TasklistTestSuite) Test_SnoozeTaskSignal() {
+	var tasks *todo.Tasks
+	start := time.Date(2021, 8, 10, 10, 0, 0, 0, time.UTC)
+	dailyTask := func() *todopb.TodoItem {
+		return &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.New(start),
+			Reminder: &todopb.Reminder{
+				Every: (time.Hour * 24).String(),
+			},
+		}
+	}
+	s.Scenario("snoozing pushes the next reminder and keeps the cadence",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, dailyTask())),
+		s.And(ProxySignalSucceeded(time.Hour*1, todo.SnoozeTaskSignal, MustMarshalAny(&todopb.SnoozeTodoRequest{
+			Uuid:     "t1",
+			Duration: (time.Hour * 30).String(),
+		}), nil)),
+		s.And(snoozedUntilIn(time.Hour*2, start.Add(time.Hour*31))),
+		s.And(snoozedUntilIn(time.Hour*32, time.Time{})),
+		s.And(aDeliveryPolicy(nil)),
+		s.And(remindersSentOn(
+			start.Add(time.Hour*31),
+			start.Add(time.Hour*55),
+			start.Add(time.Hour*79),
+			start.Add(time.Hour*103),
+			start.Add(time.Hour*127),
+			start.Add(time.Hour*151),
+		)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("snoozing a cron reminder until a time keeps the schedule",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.New(start),
+			Reminder: &todopb.Reminder{
+				Cron: "0 9 * * *",
+			},
+		})),
+		s.And(ProxySignalSucceeded(time.Hour*1, todo.SnoozeTaskSignal, MustMarshalAny(&todopb.SnoozeTodoRequest{
+			Uuid:  "t1",
+			Until: timestamppb.New(time.Date(2021, 8, 11, 15, 0, 0, 0, time.UTC)),
+		}), nil)),
+		s.And(aDeliveryPolicy(nil)),
+		s.And(remindersSentOn(
+			time.Date(2021, 8, 11, 15, 0, 0, 0, time.UTC),
+			time.Date(2021, 8, 12, 9, 0, 0, 0, time.UTC),
+			time.Date(2021, 8, 13, 9, 0, 0, 0, time.UTC),
+			time.Date(2021, 8, 14, 9, 0, 0, 0, time.UTC),
+			time.Date(2021, 8, 15, 9, 0, 0, 0, time.UTC),
+			time.Date(2021, 8, 16, 9, 0, 0, 0, time.UTC),
+			time.Date(2021, 8, 17, 9, 0, 0, 0, time.UTC),
+		)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("updating the reminder clears the snooze",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, dailyTask())),
+		s.And(ProxySignalSucceeded(time.Hour*1, todo.SnoozeTaskSignal, MustMarshalAny(&todopb.SnoozeTodoRequest{
+			Uuid:     "t1",
+			Duration: (time.Hour * 30).String(),
+		}), nil)),
+		s.And(ProxySignalSucceeded(time.Hour*2, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item: &todopb.TodoItem{
+				Uuid:        "t1",
+				Description: "some task",
+				Reminder: &todopb.Reminder{
+					Every: (time.Hour * 168).String(),
+				},
+			},
+		}), nil)),
+		s.And(snoozedUntilIn(time.Hour*3, time.Time{})),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("snoozing a todo without reminder fails",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.Now(),
+		})),
+		s.And(ProxySignalErrored(time.Minute*1, todo.SnoozeTaskSignal, MustMarshalAny(&todopb.SnoozeTodoRequest{
+			Uuid:     "t1",
+			Duration: "2h",
+		}), "todo has no reminder to snooze")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("snoozing into the past fails",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.New(start),
+		})),
+		s.And(ProxySignalErrored(time.Minute*1, todo.SnoozeTaskSignal, MustMarshalAny(&todopb.SnoozeTodoRequest{
+			Uuid:  "t1",
+			Until: timestamppb.New(start.Add(-time.Hour)),
+		}), "invalid snooze until")),
+		s.And(ProxySignalErrored(time.Minute*2, todo.SnoozeTaskSignal, MustMarshalAny(&todopb.SnoozeTodoRequest{
+			Uuid:     "t1",
+			Duration: "-2h",
+		}), "invalid snooze duration")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+}
+
+func (s *TasklistTestSuite) Test_DueDates() {
+	var tasks *todo.Tasks
+	start := time.Date(2021, 8, 10, 10, 0, 0, 0, time.UTC)
+	taskDueAt := func(dueAt time.Time) *todopb.TodoItem {
+		return &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.New(start),
+			DueAt:       timestamppb.New(dueAt),
+		}
+	}
+	s.Scenario("due soon and overdue are notified once",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, taskDueAt(start.Add(time.Hour*48)))),
+		s.And(aDeliveryPolicy(nil)),
+		s.And(dueNotifiedOn(map[string]time.Duration{
+			"due_soon": time.Hour * 24,
+			"overdue":  0,
+		}, start.Add(time.Hour*24), start.Add(time.Hour*48))),
+		s.And(dueQueriedIn(time.Hour, todo.AllTasksQuery, "due in 2 days")),
+		s.And(dueQueriedIn(time.Hour, todo.OverdueTasksQuery)),
+		s.And(dueQueriedIn(time.Hour*50, todo.OverdueTasksQuery, "2 hours overdue")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("the due soon offset carried from the last run is kept",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(func(suite **BDTemporalTestSuite) {
+			tasks = &todo.Tasks{
+				Items:         []*todopb.TodoItem{taskDueAt(start.Add(time.Hour * 48))},
+				DueSoonBefore: time.Hour * 2,
+			}
+		}),
+		s.And(aDeliveryPolicy(nil)),
+		s.And(dueNotifiedOn(map[string]time.Duration{
+			"due_soon": time.Hour * 2,
+			"overdue":  0,
+		}, start.Add(time.Hour*46), start.Add(time.Hour*48))),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("todo added overdue skips the due soon notification",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, taskDueAt(start.Add(-time.Hour*2)))),
+		s.And(aDeliveryPolicy(nil)),
+		s.And(dueNotifiedOn(map[string]time.Duration{
+			"overdue": -time.Hour * 2,
+		}, start)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("completing a todo cancels its due notifications",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, taskDueAt(start.Add(time.Hour*48)))),
+		s.And(ProxySignalSucceeded(time.Hour, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item: &todopb.TodoItem{
+				Uuid:        "t1",
+				Description: "some task",
+				CompletedAt: timestamppb.New(start.Add(time.Hour)),
+				CompletedBy: dummyUser.SamAccountName,
+				DueAt:       timestamppb.New(start.Add(time.Hour * 48)),
+			},
+		}), nil)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowFinished()),
+	)
+}
+
+func (s *TasklistTestSuite) Test_ReminderHistory() {
+	var tasks *todo.Tasks
+	start := time.Date(2021, 8, 10, 10, 0, 0, 0, time.UTC)
+	taskRemindedAt := func(at time.Time, history ...*todopb.ReminderAttempt) *todopb.TodoItem {
+		return &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.New(start),
+			Reminder: &todopb.Reminder{
+				At: timestamppb.New(at),
+			},
+			ReminderHistory: history,
+		}
+	}
+	s.Scenario("failed reminder is recorded with its error",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, taskRemindedAt(start.Add(time.Hour)))),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			act := &todo2.Activities{}
+			wfData := &todo2.WorkflowData{
+				AssignedSince: time.Hour,
+			}
+			st.Env.OnActivity(act.FetchUser, mock.Anything, dummyUser.SamAccountName).Return(dummyUser, nil).Times(1)
+			st.Env.OnActivity(act.CollectWorkflowData, mock.Anything, taskWithUuid("t1"), mock.Anything).Return(wfData, nil).Times(1)
+			st.Env.OnActivity(act.PrepareReminderModel, mock.Anything, wfData, wfData.AssignedSince, "some task", dummyUser).Return(dummyReminderModel, nil).Times(1)
+			st.Env.OnActivity(act.SendTaskReminder, mock.Anything, dummyReminderModel, []string{dummyUser.EmailAddress}).Return(temporal.NewNonRetryableApplicationError("mail server unavailable", "SendError", nil)).Times(1)
+		}),
+		s.And(aDeliveryPolicy(nil)),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			st.Env.RegisterDelayedCallback(func() {
+				value, err := st.Env.QueryWorkflow(todo.AllTasksQuery)
+				st.NoError(err)
+				var items []*todopb.TodoItem
+				st.NoError(value.Get(&items))
+				st.Equal(int32(0), items[0].RemindersSent)
+				if st.Len(items[0].ReminderHistory, 1) {
+					attempt := items[0].ReminderHistory[0]
+					st.True(start.Add(time.Hour).Equal(attempt.At.AsTime()), "attempted at %s", attempt.At.AsTime())
+					st.Equal("email", attempt.Channel)
+					st.Equal("reminder", attempt.Kind)
+					st.Equal(dummyUser.EmailAddress, attempt.Recipient)
+					st.False(attempt.Success)
+					st.Contains(attempt.Error, "mail server unavailable")
+				}
+			}, time.Hour*2)
+		}),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	var fullHistory []*todopb.ReminderAttempt
+	for i := 20; i > 0; i-- {
+		fullHistory = append(fullHistory, &todopb.ReminderAttempt{
+			At:        timestamppb.New(start.Add(-time.Hour * time.Duration(i))),
+			Channel:   "email",
+			Recipient: dummyUser.EmailAddress,
+			Kind:      "reminder",
+			Success:   true,
+		})
+	}
+	s.Scenario("history keeps the latest attempts",
+		s.setupMocks,
+		s.Given(startingAt(start)),
+		s.And(aTasklist(&tasks, taskRemindedAt(start.Add(time.Hour), fullHistory...))),
+		s.And(remindersSentOn(start.Add(time.Hour))),
+		s.And(aDeliveryPolicy(nil)),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			st.Env.RegisterDelayedCallback(func() {
+				value, err := st.Env.QueryWorkflow(todo.AllTasksQuery)
+				st.NoError(err)
+				var items []*todopb.TodoItem
+				st.NoError(value.Get(&items))
+				history := items[0].ReminderHistory
+				if st.Len(history, 20) {
+					st.True(start.Add(-time.Hour*19).Equal(history[0].At.AsTime()), "oldest attempt at %s", history[0].At.AsTime())
+					st.True(start.Add(time.Hour).Equal(history[19].At.AsTime()), "latest attempt at %s", history[19].At.AsTime())
+					st.True(history[19].Success)
+				}
+			}, time.Hour*2)
+		}),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+}
+
+func (s *TasklistTestSuite) Test_Reminders() {
+	var tasks *todo.Tasks
+	oneDayHours := 24
+	dummyTask := &todopb.TodoItem{
+		Uuid:        "t1",
+		Description: "some task",
+		CreatedBy:   dummyUser.SamAccountName,
+		CreatedAt:   timestamppb.Now(),
+		Reminder: &todopb.Reminder{
+			Every: (time.Hour * time.Duration(oneDayHours)).String(),
+		},
+	}
+	dummyTaskWith1Reminder := &todopb.TodoItem{
+		Uuid:        "t1",
+		Description: "some task",
+		CreatedBy:   dummyUser.SamAccountName,
+		CreatedAt:   timestamppb.Now(),
+		Reminder: &todopb.Reminder{
+			At: timestamppb.New(time.Now().Add(time.Hour * 48)),
+		},
+	}
+	afterDays := 4
+	dummyTaskWithDailyReminderAfter := &todopb.TodoItem{
+		Uuid:        "t1",
+		Description: "some task",
+		CreatedBy:   dummyUser.SamAccountName,
+		CreatedAt:   timestamppb.Now(),
+		Reminder: &todopb.Reminder{
+			At:    timestamppb.New(time.Now().Add(time.Hour * time.Duration(oneDayHours) * time.Duration(afterDays))),
+			Every: (time.Hour * time.Duration(oneDayHours)).String(),
+		},
+	}
+	everyDayReminderCount := todo.MaxHoursPerRun/oneDayHours - 1
+	s.Scenario("reminder every day",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, dummyTask)),
+		s.And(queryTasksIn(time.Minute*1, todo.PendingTasksQuery, dummyTask)),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			act := &todo2.Activities{}
+			st.Env.OnActivity(act.FetchUser, mock.Anything, dummyUser.SamAccountName).Return(dummyUser, nil).Times(everyDayReminderCount)
+			wfData := &todo2.WorkflowData{
+				AssignedSince: time.Minute * 60,
+			}
+			st.Env.OnActivity(act.CollectWorkflowData, mock.Anything, taskWithUuid("t1"), mock.Anything).Return(wfData, nil).Times(everyDayReminderCount)
+			st.Env.OnActivity(act.PrepareReminderModel, mock.Anything, wfData, wfData.AssignedSince, "some task", dummyUser).Return(dummyReminderModel, nil).Times(everyDayReminderCount)
+			st.Env.OnActivity(act.SendTaskReminder, mock.Anything, mock.Anything, []string{dummyUser.EmailAddress}).Return(nil).Times(everyDayReminderCount)
+		}),
+		s.And(aDeliveryPolicy(nil)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("reminder every day update to less frequent",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, dummyTask)),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			act := &todo2.Activities{}
+			st.Env.OnActivity(act.FetchUser, mock.Anything, dummyUser.SamAccountName).Return(dummyUser, nil).Times(2)
+			wfData := &todo2.WorkflowData{
+				AssignedSince: time.Minute * 60,
+			}
+			st.Env.OnActivity(act.CollectWorkflowData, mock.Anything, taskWithUuid("t1"), mock.Anything).Return(wfData, nil).Times(2)
+			st.Env.OnActivity(act.PrepareReminderModel, mock.Anything, wfData, wfData.AssignedSince, "some task", dummyUser).Return(dummyReminderModel, nil).Times(2)
+			st.Env.OnActivity(act.SendTaskReminder, mock.Anything, mock.Anything, []string{dummyUser.EmailAddress}).Return(nil).Times(2)
+		}),
+		s.And(ProxySignalSucceeded(time.Hour*49, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item: &todopb.TodoItem{
+				Uuid:        "t1",
+				Description: "some task",
+				CreatedBy:   dummyUser.SamAccountName,
+				CreatedAt:   timestamppb.Now(),
+				Reminder: &todopb.Reminder{
+					Every: (time.Hour * 168).String(),
+				},
+			},
+		}), nil)),
+		s.And(aDeliveryPolicy(nil)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("reminder in 2 days",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, dummyTaskWith1Reminder)),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			act := &todo2.Activities{}
+			st.Env.OnActivity(act.FetchUser, mock.Anything, dummyUser.SamAccountName).Return(dummyUser, nil).Times(1)
+			wfData := &todo2.WorkflowData{
+				AssignedSince: time.Minute * 60,
+			}
+			st.Env.OnActivity(act.CollectWorkflowData, mock.Anything, taskWithUuid("t1"), mock.Anything).Return(wfData, nil).Times(1)
+			st.Env.OnActivity(act.PrepareReminderModel, mock.Anything, wfData, wfData.AssignedSince, "some task", dummyUser).Return(dummyReminderModel, nil).Times(1)
+			st.Env.OnActivity(act.SendTaskReminder, mock.Anything, mock.Anything, []string{dummyUser.EmailAddress}).Return(nil).Times(1)
+		}),
+		s.And(aDeliveryPolicy(nil)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("sent reminders are counted",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.Now(),
+			Reminder: &todopb.Reminder{
+				At: timestamppb.New(time.Now().Add(time.Hour * 48)),
+			},
+		}, &todopb.TodoItem{
+			Uuid:        "t2",
+			Description: "some task 2",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.Now(),
+			CompletedAt: timestamppb.Now(),
+		})),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			act := &todo2.Activities{}
+			stats := todo2.TasklistStats{Pending: 1, Completed: 1}
+			wfData := &todo2.WorkflowData{
+				AssignedSince: time.Hour * 48,
+				Completed:     1,
+			}
+			st.Env.OnActivity(act.FetchUser, mock.Anything, dummyUser.SamAccountName).Return(dummyUser, nil).Times(1)
+			st.Env.OnActivity(act.CollectWorkflowData, mock.Anything, taskWithUuid("t1"), stats).Return(wfData, nil).Times(1)
+			st.Env.OnActivity(act.PrepareReminderModel, mock.Anything, wfData, wfData.AssignedSince, "some task", dummyUser).Return(dummyReminderModel, nil).Times(1)
+			st.Env.OnActivity(act.SendTaskReminder, mock.Anything, dummyReminderModel, []string{dummyUser.EmailAddress}).Return(nil).Times(1)
+		}),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			st.Env.RegisterDelayedCallback(func() {
+				value, err := st.Env.QueryWorkflow(todo.AllTasksQuery)
+				st.NoError(err)
+				var items []*todopb.TodoItem
+				st.NoError(value.Get(&items))
+				st.Equal(int32(1), items[0].RemindersSent)
+			}, time.Hour*72)
+		}),
+		s.And(aDeliveryPolicy(nil)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("reminder on weekdays at 9",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.Now(),
+			Reminder: &todopb.Reminder{
+				Cron: "0 9 * * 1-5",
+			},
+		})),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			act := &todo2.Activities{}
+			weekdays := 5
+			wfData := &todo2.WorkflowData{
+				AssignedSince: time.Minute * 60,
+			}
+			st.Env.OnActivity(act.FetchUser, mock.Anything, dummyUser.SamAccountName).Return(dummyUser, nil).Times(weekdays)
+			st.Env.OnActivity(act.CollectWorkflowData, mock.Anything, taskWithUuid("t1"), mock.Anything).Return(wfData, nil).Times(weekdays)
+			st.Env.OnActivity(act.PrepareReminderModel, mock.Anything, wfData, wfData.AssignedSince, "some task", dummyUser).Return(dummyReminderModel, nil).Times(weekdays)
+			st.Env.OnActivity(act.SendTaskReminder, mock.Anything, dummyReminderModel, []string{dummyUser.EmailAddress}).Return(func(ctx context.Context, model *todopb.TaskReminderModel, addressee []string) error {
+				now := st.Env.Now()
+				st.Equal(9, now.Hour(), "reminder should be sent at 9")
+				st.NotContains([]time.Weekday{time.Saturday, time.Sunday}, now.Weekday(), "reminder should be sent on weekdays")
+				return nil
+			}).Times(weekdays)
+		}),
+		s.And(aDeliveryPolicy(nil)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("daily reminder keeps its local time across DST",
+		s.setupMocks,
+		s.Given(startingAt(berlinBeforeDst)),
+		s.And(aTasklist(&tasks, &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.New(berlinBeforeDst),
+			Reminder: &todopb.Reminder{
+				// the first midnight is the night of the transition to summer time
+				At:       timestamppb.New(berlinBeforeDst.Add(TimeUntilMidnight(berlinBeforeDst, berlin))),
+				Every:    (time.Hour * 24).String(),
+				Timezone: berlin.String(),
+			},
+		})),
+		s.And(remindersSentAt(7, berlin, 0)),
+		s.And(aDeliveryPolicy(nil)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("cron reminder keeps its local time across DST",
+		s.setupMocks,
+		s.Given(startingAt(newYorkBeforeDst)),
+		s.And(aTasklist(&tasks, &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.New(newYorkBeforeDst),
+			Reminder: &todopb.Reminder{
+				Cron:     "0 9 * * *",
+				Timezone: newYork.String(),
+			},
+		})),
+		s.And(remindersSentAt(7, newYork, 9)),
+		s.And(aDeliveryPolicy(nil)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("updating a todo with an unknown timezone fails",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.Now(),
+		})),
+		s.And(ProxySignalErrored(time.Minute*1, todo.UpdateTaskSignal, MustMarshalAny(&todopb.UpdateTodoRequest{
+			Item: &todopb.TodoItem{
+				Uuid:        "t1",
+				Description: "some task",
+				Reminder: &todopb.Reminder{
+					Cron:     "@daily",
+					Timezone: "Mars/Olympus_Mons",
+				},
+			},
+		}), "invalid reminder timezone")),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("reminder every day after 4 days",
+		s.setupMocks,
+		s.Given(aTasklist(&tasks, dummyTaskWithDailyReminderAfter)),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			act := &todo2.Activities{}
+			// the first reminder is set just before the workflow starts, so the last one fires before the run ends
+			callCount := todo.MaxHoursPerRun/oneDayHours - afterDays + 1
+			st.Env.OnActivity(act.FetchUser, mock.Anything, dummyUser.SamAccountName).Return(dummyUser, nil).Times(callCount)
+			wfData := &todo2.WorkflowData{
+				AssignedSince: time.Minute * 60,
+			}
+			st.Env.OnActivity(act.CollectWorkflowData, mock.Anything, taskWithUuid("t1"), mock.Anything).Return(wfData, nil).Times(callCount)
+			st.Env.OnActivity(act.PrepareReminderModel, mock.Anything, wfData, wfData.AssignedSince, "some task", dummyUser).Return(dummyReminderModel, nil).Times(callCount)
+			st.Env.OnActivity(act.SendTaskReminder, mock.Anything, mock.Anything, []string{dummyUser.EmailAddress}).Return(nil).Times(callCount)
+		}),
+		s.And(aDeliveryPolicy(nil)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("reminder is deferred past the weekend and a holiday",
+		s.setupMocks,
+		s.Given(startingAt(berlinSaturday)),
+		s.And(aTasklist(&tasks, &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.New(berlinSaturday),
+			Reminder: &todopb.Reminder{
+				At: timestamppb.New(berlinSaturday.Add(time.Hour)),
+			},
+		})),
+		s.And(aDeliveryPolicy(&calendar.Policy{
+			Timezone:    berlin.String(),
+			QuietStart:  time.Hour * 18,
+			QuietEnd:    time.Hour * 8,
+			WorkingDays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+			Holidays:    []string{"2021-08-09"},
+		})),
+		s.And(func(suite **BDTemporalTestSuite) {
+			st := *suite
+			act := &todo2.Activities{}
+			wfData := &todo2.WorkflowData{
+				AssignedSince: time.Minute * 60,
+			}
+			st.Env.OnActivity(act.FetchUser, mock.Anything, dummyUser.SamAccountName).Return(dummyUser, nil).Times(1)
+			st.Env.OnActivity(act.CollectWorkflowData, mock.Anything, taskWithUuid("t1"), mock.Anything).Return(wfData, nil).Times(1)
+			st.Env.OnActivity(act.PrepareReminderModel, mock.Anything, wfData, wfData.AssignedSince, "some task", dummyUser).Return(dummyReminderModel, nil).Times(1)
+			st.Env.OnActivity(act.SendTaskReminder, mock.Anything, dummyReminderModel, []string{dummyUser.EmailAddress}).Return(func(ctx context.Context, model *todopb.TaskReminderModel, addressee []string) error {
+				// monday is a holiday, tuesday morning is the first working time
+				expected := time.Date(2021, 8, 10, 8, 0, 0, 0, berlin)
+				st.True(expected.Equal(st.Env.Now()), "reminder sent at "+st.Env.Now().In(berlin).String())
+				return nil
+			}).Times(1)
+		}),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+	s.Scenario("reminder in quiet hours is deferred to their end",
+		s.setupMocks,
+		s.Given(startingAt(berlinSaturday)),
+		s.And(aTasklist(&tasks, &todopb.TodoItem{
+			Uuid:        "t1",
+			Description: "some task",
+			CreatedBy:   dummyUser.SamAccountName,
+			CreatedAt:   timestamppb.New(berlinSaturday),
+			Reminder: &todopb.Reminder{
+				Cron:     "0 23 * * *",
+				Timezone: berlin.String(),
+			},
+		})),
+		s.And(aDeliveryPolicy(&calendar.Policy{
+			Timezone:   berlin.String(),
+			QuietStart: time.Hour * 22,
+			QuietEnd:   time.Hour * 7,
+		})),
+		s.And(remindersSentAt(7, berlin, 7)),
+		s.When(startAWorkflow(&tasks)),
+		s.Then(WorkflowContinuedAsNew()),
+	)
+}
+
+func startingAt(start time.Time) func(suite **BDTemporalTestSuite) {
+	return func(suite **BDTemporalTestSuite) {
+		(*suite).Env.SetStartTime(start)
+	}
+}
+
+// remindersSentAt expects count reminders, each sent at the full hour in the timezone
+func remindersSentAt(count int, timezone *time.Location, hour int) func(suite **BDTemporalTestSuite) {
+	return func(suite **BDTemporalTestSuite) {
+		st := *suite
+		act := &todo2.Activities{}
+		wfData := &todo2.WorkflowData{
+			AssignedSince: time.Minute * 60,
+		}
+		st.Env.OnActivity(act.FetchUser, mock.Anything, dummyUser.SamAccountName).Return(dummyUser, nil).Times(count)
+		st.Env.OnActivity(act.CollectWorkflowData, mock.Anything, taskWithUuid("t1"), mock.Anything).Return(wfData, nil).Times(count)
+		st.Env.OnActivity(act.PrepareReminderModel, mock.Anything, wfData, wfData.AssignedSince, "some task", dummyUser).Return(dummyReminderModel, nil).Times(count)
+		st.Env.OnActivity(act.SendTaskReminder, mock.Anything, dummyReminderModel, []string{dummyUser.EmailAddress}).Return(func(ctx context.Context, model *todopb.TaskReminderModel, addressee []string) error {
+			now := st.Env.Now().In(timezone)
+			st.Equal(hour, now.Hour(), "reminder sent at "+now.String())
+			st.Equal(0, now.Minute(), "reminder sent at "+now.String())
+			return nil
+		}).Times(count)
+	}
+}
+
+// remindersSentOn expects a reminder to be sent at each of the times, in order
+func remindersSentOn(times ...time.Time) func(suite **BDTemporalTestSuite) {
+	return func(suite **BDTemporalTestSuite) {
+		st := *suite
+		act := &todo2.Activities{}
+		wfData := &todo2.WorkflowData{
+			AssignedSince: time.Minute * 60,
+		}
+		sent := 0
+		st.Env.OnActivity(act.FetchUser, mock.Anything, dummyUser.SamAccountName).Return(dummyUser, nil).Times(len(times))
+		st.Env.OnActivity(act.CollectWorkflowData, mock.Anything, taskWithUuid("t1"), mock.Anything).Return(wfData, nil).Times(len(times))
+		st.Env.OnActivity(act.PrepareReminderModel, mock.Anything, wfData, wfData.AssignedSince, "some task", dummyUser).Return(dummyReminderModel, nil).Times(len(times))
+		st.Env.OnActivity(act.SendTaskReminder, mock.Anything, dummyReminderModel, []string{dummyUser.EmailAddress}).Return(func(ctx context.Context, model *todopb.TaskReminderModel, addressee []string) error {
+			st.True(times[sent].Equal(st.Env.Now()), "reminder %d sent at %s instead of %s", sent, st.Env.Now(), times[sent])
+			sent++
+			return nil
+		}).Times(len(times))
+	}
+}
+
+// snoozedUntilIn queries the snoozed until time of t1 after the delay, the zero time expects no snooze
+func snoozedUntilIn(delay time.Duration, expected time.Time) func(suite **BDTemporalTestSuite) {
+	return func(suite **BDTemporalTestSuite) {
+		st := *suite
+		st.Env.RegisterDelayedCallback(func() {
+			value, err := st.Env.QueryWorkflow(todo.AllTasksQuery)
+			st.NoError(err)
+			var items []*todopb.TodoItem
+			st.NoError(value.Get(&items))
+			if expected.IsZero() {
+				st.Nil(items[0].SnoozedUntil)
+			} else if st.NotNil(items[0].SnoozedUntil) {
+				st.True(expected.Equal(items[0].SnoozedUntil.AsTime()), "snoozed until %s", items[0].SnoozedUntil.AsTime())
+			}
+		}, delay)
+	}
+}
+
+// dueNotifiedOn expects the due notifications about t1 at the given times, rendered with the templates mapped to the
+// time left until the due date
+func dueNotifiedOn(dueIn map[string]time.Duration, times ...time.Time) func(suite **BDTemporalTestSuite) {
+	return func(suite **BDTemporalTestSuite) {
+		st := *suite
+		act := &todo2.Activities{}
+		wfData := &todo2.WorkflowData{
+			AssignedSince: time.Minute * 60,
+		}
+		sent := 0
+		st.Env.OnActivity(act.FetchUser, mock.Anything, dummyUser.SamAccountName).Return(dummyUser, nil).Times(len(times))
+		st.Env.OnActivity(act.CollectWorkflowData, mock.Anything, taskWithUuid("t1"), mock.Anything).Return(wfData, nil).Times(len(times))
+		for template, d := range dueIn {
+			st.Env.OnActivity(act.PrepareDueModel, mock.Anything, wfData, template, "some task", d, dummyUser).Return(dummyReminderModel, nil).Once()
+		}
+		st.Env.OnActivity(act.SendTaskReminder, mock.Anything, dummyReminderModel, []string{dummyUser.EmailAddress}).Return(func(ctx context.Context, model *todopb.TaskReminderModel, addressee []string) error {
+			st.True(times[sent].Equal(st.Env.Now()), "due notification %d sent at %s instead of %s", sent, st.Env.Now(), times[sent])
+			sent++
+			return nil
+		}).Times(len(times))
+	}
+}
+
+// unblockedNotifiedAt expects the blocked todo t2 to be notified once, at the time, about its completed blocker
+func unblockedNotifiedAt(stillBlockedBy int, at time.Time) func(suite **BDTemporalTestSuite) {
+	return func(suite **BDTemporalTestSuite) {
+		st := *suite
+		act := &todo2.Activities{}
+		wfData := &todo2.WorkflowData{
+			AssignedSince: time.Minute * 60,
+		}
+		st.Env.OnActivity(act.FetchUser, mock.Anything, dummyUser.SamAccountName).Return(dummyUser, nil).Once()
+		st.Env.OnActivity(act.CollectWorkflowData, mock.Anything, taskWithUuid("t2"), mock.Anything).Return(wfData, nil).Once()
+		st.Env.OnActivity(act.PrepareUnblockedModel, mock.Anything, wfData, "some blocked task", "some blocker", stillBlockedBy, dummyUser).Return(dummyReminderModel, nil).Once()
+		st.Env.OnActivity(act.SendTaskReminder, mock.Anything, dummyReminderModel, []string{dummyUser.EmailAddress}).Return(func(ctx context.Context, model *todopb.TaskReminderModel, addressee []string) error {
+			st.True(at.Equal(st.Env.Now()), "unblocked notification sent at %s instead of %s", st.Env.Now(), at)
+			return nil
+		}).Once()
+	}
+}
+
+// remindedAssignee expects the assignee to be reminded about t1 once, with the addresses in cc. The assignee is
+// fetched count times, as it is looked up before a reassignment too.
+func remindedAssignee(assignee *todopb.ADUser, cc []string, count int) func(suite **BDTemporalTestSuite) {
+	return func(suite **BDTemporalTestSuite) {
+		st := *suite
+		act := &todo2.Activities{}
+		wfData := &todo2.WorkflowData{
+			AssignedSince: time.Minute * 60,
+		}
+		st.Env.OnActivity(act.FetchUser, mock.Anything, assignee.SamAccountName).Return(assignee, nil).Times(count)
+		st.Env.OnActivity(act.CollectWorkflowData, mock.Anything, taskWithUuid("t1"), mock.Anything).Return(wfData, nil).Once()
+		st.Env.OnActivity(act.PrepareReminderModel, mock.Anything, wfData, wfData.AssignedSince, "some task", assignee).Return(dummyReminderModel, nil).Once()
+		st.Env.OnActivity(act.SendTaskReminder, mock.Anything, mock.Anything, []string{assignee.EmailAddress}).Return(func(ctx context.Context, model *todopb.TaskReminderModel, addressee []string) error {
+			st.Equal(cc, model.Cc)
+			return nil
+		}).Once()
+	}
+}
+
+// historyIn runs the task history query for the todo after the delay and checks the returned history
+func historyIn(delay time.Duration, uuid string, check func(st *BDTemporalTestSuite, history *todopb.TodoHistory)) func(suite **BDTemporalTestSuite) {
+	return func(suite **BDTemporalTestSuite) {
+		st := *suite
+		st.Env.RegisterDelayedCallback(func() {
+			value, err := st.Env.QueryWorkflow(todo.TaskHistoryQuery, uuid)
+			st.NoError(err)
+			var history *todopb.TodoHistory
+			st.NoError(value.Get(&history))
+			check(st, history)
+		}, delay)
+	}
+}
+
+// occurrencesIn runs the all tasks query after the delay and checks the returned todos
+func occurrencesIn(delay time.Duration, check func(st *BDTemporalTestSuite, items []*todopb.TodoItem)) func(suite **BDTemporalTestSuite) {
+	return func(suite **BDTemporalTestSuite) {
+		st := *suite
+		st.Env.RegisterDelayedCallback(func() {
+			value, err := st.Env.QueryWorkflow(todo.AllTasksQuery)
+			st.NoError(err)
+			var items []*todopb.TodoItem
+			st.NoError(value.Get(&items))
+			check(st, items)
+		}, delay)
+	}
+}
+
+// dueQueriedIn runs the query after the delay and expects the due texts of the returned todos
+func dueQueriedIn(delay time.Duration, queryType string, expectedDue ...string) func(suite **BDTemporalTestSuite) {
+	return func(suite **BDTemporalTestSuite) {
+		st := *suite
+		st.Env.RegisterDelayedCallback(func() {
+			value, err := st.Env.QueryWorkflow(queryType)
+			st.NoError(err)
+			var items []*todopb.TodoItem
+			st.NoError(value.Get(&items))
+			due := make([]string, 0, len(items))
+			for _, item := range items {
+				due = append(due, item.Due)
+			}
+			st.Equal(append([]string{}, expectedDue...), due)
+		}, delay)
+	}
+}
+
+// aDeliveryPolicy returns the policy from the one delivery policy lookup of the run
+func aDeliveryPolicy(policy *calendar.Policy) func(suite **BDTemporalTestSuite) {
+	return func(suite **BDTemporalTestSuite) {
+		act := &todo2.Activities{}
+		(*suite).Env.OnActivity(act.FetchDeliveryPolicy, mock.Anything, mock.Anything).Return(policy, nil).Once()
+	}
+}
+
+func escalationTo(target todopb.EscalationLevel_Target) interface{} {
+	return mock.MatchedBy(func(level *todopb.EscalationLevel) bool {
+		return level.Target == target
+	})
+}
+
+func taskWithUuid(uuid string) interface{} {
+	return mock.MatchedBy(func(item *todopb.TodoItem) bool {
+		return item.Uuid == uuid
+	})
+}
+
+func startAWorkflow(t **todo.Tasks) func(suite **BDTemporalTestSuite) {
+	return func(suite **BDTemporalTestSuite) {
 		st := *suite
 		st.Env.ExecuteWorkflow(todo.Tasklist, *t)
 	}
@@ -446,6 +2542,44 @@ func aTasklist(t **todo.Tasks, items ...*todopb.TodoItem) func(suite **BDTempora
 	}
 }
 
+// filteredTasksIn runs the filtered tasks query after the delay and expects the todos with the uuids
+func filteredTasksIn(delay time.Duration, filter *todopb.TodoFilter, expectedUuids ...string) func(s **BDTemporalTestSuite) {
+	return func(s **BDTemporalTestSuite) {
+		st := *s
+		st.Env.RegisterDelayedCallback(func() {
+			value, err := st.Env.QueryWorkflow(todo.FilteredTasksQuery, filter)
+			st.NoError(err)
+			var items []*todopb.TodoItem
+			st.NoError(value.Get(&items))
+			uuids := make([]string, 0, len(items))
+			for _, item := range items {
+				uuids = append(uuids, item.Uuid)
+			}
+			st.Equal(append([]string{}, expectedUuids...), uuids, "filter %v", filter)
+		}, delay)
+	}
+}
+
+// revisionIn checks the revision of the todo with the uuid after the delay
+func revisionIn(delay time.Duration, uuid string, revision int64) func(s **BDTemporalTestSuite) {
+	return func(s **BDTemporalTestSuite) {
+		st := *s
+		st.Env.RegisterDelayedCallback(func() {
+			value, err := st.Env.QueryWorkflow(todo.AllTasksQuery)
+			st.NoError(err)
+			var items []*todopb.TodoItem
+			st.NoError(value.Get(&items))
+			for _, item := range items {
+				if item.Uuid == uuid {
+					st.Equal(revision, item.Revision, "unexpected revision of todo %s", uuid)
+					return
+				}
+			}
+			st.Fail("todo not found", uuid)
+		}, delay)
+	}
+}
+
 func queryTasksIn(delay time.Duration, queryType string, expectedTasks ...*todopb.TodoItem) func(s **BDTemporalTestSuite) {
 	return func(s **BDTemporalTestSuite) {
 		st := *s
@@ -1,6 +1,7 @@
 package workflows
 
 import (
+	"fmt"
 	"github.com/nadilas/todo/config"
 	interfaces "github.com/nadilas/todo/if"
 	todo2 "github.com/nadilas/todo/todo"
@@ -9,18 +10,61 @@ import (
 	"go.temporal.io/sdk/worker"
 )
 
+const (
+	TasklistService    = "tasklist"
+	SignalProxyService = "signalproxy"
+)
+
+// Services lists every service which can be registered on a worker
+var Services = []string{
+	TasklistService,
+	SignalProxyService,
+}
+
+// ValidateServices returns an error for the first service which is not known
+func ValidateServices(services ...string) error {
+	for _, service := range services {
+		if !contains(Services, service) {
+			return fmt.Errorf("unknown service: %s", service)
+		}
+	}
+	return nil
+}
+
+// Register registers the workflows and activities of the given services on the worker. If no service is given, all
+// of them are registered.
 func Register(worker worker.Worker, configProvider config.Provider, serviceContainer interfaces.Kernel, services ...string) {
+	if len(services) == 0 {
+		services = Services
+	}
+
 	// region Tasklist
-	worker.RegisterWorkflow(todo.Tasklist)
-	todoActivities := todo2.NewActivities(
-		configProvider,
-		serviceContainer.InjectEmailService(),
-		serviceContainer.InjectActiveDirectoryService(),
-	)
-	worker.RegisterActivity(todoActivities)
+	if contains(services, TasklistService) {
+		if configProvider != nil {
+			todo.Configure(configProvider)
+		}
+		worker.RegisterWorkflow(todo.Tasklist)
+		todoActivities := todo2.NewActivities(
+			configProvider,
+			serviceContainer.InjectEmailService(),
+			serviceContainer.InjectActiveDirectoryService(),
+		)
+		worker.RegisterActivity(todoActivities)
+	}
 	// endregion
 
 	// region SignalProxy
-	worker.RegisterWorkflow(signalproxy.SignalProxy)
+	if contains(services, SignalProxyService) {
+		worker.RegisterWorkflow(signalproxy.SignalProxy)
+	}
 	// endregion
 }
+
+func contains(services []string, service string) bool {
+	for _, s := range services {
+		if s == service {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package workflows_test
+
+import (
+	"github.com/nadilas/todo/config"
+	"github.com/nadilas/todo/services"
+	"github.com/nadilas/todo/workflows"
+	. "github.com/nadilas/todo/workflows/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/worker"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	var w *recordingWorker
+
+	Scenario(t, "all services are registered by default",
+		Given(aWorker(&w)),
+		When(servicesRegistered(&w)),
+		Then(workflowsRegistered(&w, "Tasklist", "SignalProxy")),
+		And(activitiesRegistered(&w, 1)),
+	)
+	Scenario(t, "only the signal proxy is registered",
+		Given(aWorker(&w)),
+		When(servicesRegistered(&w, workflows.SignalProxyService)),
+		Then(workflowsRegistered(&w, "SignalProxy")),
+		And(activitiesRegistered(&w, 0)),
+	)
+	Scenario(t, "only the tasklist is registered",
+		Given(aWorker(&w)),
+		When(servicesRegistered(&w, workflows.TasklistService)),
+		Then(workflowsRegistered(&w, "Tasklist")),
+		And(activitiesRegistered(&w, 1)),
+	)
+}
+
+func TestValidateServices(t *testing.T) {
+	assert.NoError(t, workflows.ValidateServices(workflows.Services...))
+	assert.EqualError(t, workflows.ValidateServices(workflows.TasklistService, "mailer"), "unknown service: mailer")
+}
+
+func aWorker(w **recordingWorker) func(t *testing.T) {
+	return func(t *testing.T) {
+		*w = &recordingWorker{}
+	}
+}
+
+func servicesRegistered(w **recordingWorker, enabled ...string) func(t *testing.T) {
+	return func(t *testing.T) {
+		configProvider, err := config.Load("")
+		require.NoError(t, err)
+		workflows.Register(*w, configProvider, services.ServiceContainer(configProvider), enabled...)
+	}
+}
+
+func workflowsRegistered(w **recordingWorker, expected ...string) func(t *testing.T) {
+	return func(t *testing.T) {
+		assert.Equal(t, expected, (*w).workflows)
+	}
+}
+
+func activitiesRegistered(w **recordingWorker, expected int) func(t *testing.T) {
+	return func(t *testing.T) {
+		assert.Len(t, (*w).activities, expected)
+	}
+}
+
+// recordingWorker records the registrations instead of polling a task queue
+type recordingWorker struct {
+	worker.Worker
+	workflows  []string
+	activities []interface{}
+}
+
+func (w *recordingWorker) RegisterWorkflow(wf interface{}) {
+	name := runtime.FuncForPC(reflect.ValueOf(wf).Pointer()).Name()
+	w.workflows = append(w.workflows, name[strings.LastIndex(name, ".")+1:])
+}
+
+func (w *recordingWorker) RegisterActivity(a interface{}) {
+	w.activities = append(w.activities, a)
+}